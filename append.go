@@ -0,0 +1,99 @@
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// AppendRowToFile appends a row to a 2D C-order .npy file, growing Shape[0]
+// by one. The header is rewritten in place, padded to its existing length,
+// when the grown header fits; otherwise the whole file is rewritten with a
+// v1.x header.
+func AppendRowToFile[T any](path string, row []T) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	// Read and validate the existing header
+	hdr, preambleLen, err := readHeader(f)
+	if err != nil {
+		return err
+	}
+	if len(hdr.Shape) != 2 {
+		return fmt.Errorf("row appends require a 2D array, got shape %v", hdr.Shape)
+	}
+	if hdr.Fortran {
+		return fmt.Errorf("row appends are not supported for Fortran-order arrays")
+	}
+	if dtype := dtypeOf[T](); dtype != hdr.DType {
+		return fmt.Errorf("cannot append %s values to a %s array", dtype, hdr.DType)
+	}
+	if len(row) != hdr.Shape[1] {
+		return fmt.Errorf("row length %d does not match %d columns", len(row), hdr.Shape[1])
+	}
+
+	// Encode the new preamble
+	grown := &Array[T]{
+		Shape: []int{hdr.Shape[0] + 1, hdr.Shape[1]},
+		DType: hdr.DType,
+		descr: hdr.Descr,
+	}
+	// Reuse the existing header space when it is large enough, as it usually
+	// is for NumPy's 64-byte aligned headers, so the data doesn't have to move
+	headerStr := generateHeader(grown, hdr.ByteOrder)
+	if hdr.Version[0] == 1 && 10+len(headerStr)+1 <= preambleLen {
+		headerStr = padHeaderTo(headerStr, preambleLen-10)
+	} else {
+		headerStr = padHeader(headerStr)
+	}
+	var preamble bytes.Buffer
+	preamble.WriteString("\x93NUMPY\x01")
+	preamble.WriteByte(v1Minor(hdr.Version))
+	binary.Write(&preamble, binary.LittleEndian, uint16(len(headerStr)))
+	preamble.WriteString(headerStr)
+
+	// Encode the row
+	var rowBuf bytes.Buffer
+	if err := writeData(&rowBuf, hdr.ByteOrder, row); err != nil {
+		return fmt.Errorf("failed to encode row: %w", err)
+	}
+
+	// The data must be followed by the row, so drop anything after it
+	dataSize, err := hdr.dataSize()
+	if err != nil {
+		return err
+	}
+	dataEnd := int64(preambleLen) + dataSize
+
+	if preamble.Len() == preambleLen {
+		// Same header length: patch the header and append the row
+		if _, err := f.WriteAt(preamble.Bytes(), 0); err != nil {
+			return fmt.Errorf("failed to write header: %w", err)
+		}
+		if _, err := f.WriteAt(rowBuf.Bytes(), dataEnd); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	} else {
+		// The header length changed, so the data has to move
+		data := make([]byte, dataSize)
+		if _, err := io.ReadFull(f, data); err != nil {
+			return fmt.Errorf("failed to read data: %w", err)
+		}
+		contents := append(preamble.Bytes(), data...)
+		contents = append(contents, rowBuf.Bytes()...)
+		if _, err := f.WriteAt(contents, 0); err != nil {
+			return fmt.Errorf("failed to rewrite file: %w", err)
+		}
+		dataEnd = int64(len(contents)) - int64(rowBuf.Len())
+	}
+
+	if err := f.Truncate(dataEnd + int64(rowBuf.Len())); err != nil {
+		return fmt.Errorf("failed to truncate file: %w", err)
+	}
+	return f.Close()
+}
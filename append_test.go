@@ -0,0 +1,154 @@
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestAppendRowToFile tests growing a 2D file one row at a time
+func TestAppendRowToFile(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Start from an empty 0x3 array
+	path := filepath.Join(tempDir, "log.npy")
+	empty := &Array[float64]{Data: []float64{}, Shape: []int{0, 3}, DType: Float64}
+	if err := WriteFile(path, empty); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	// Append three rows across calls
+	rows := [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	for _, row := range rows {
+		if err := AppendRowToFile(path, row); err != nil {
+			t.Fatalf("Failed to append row %v: %v", row, err)
+		}
+	}
+
+	arr, err := ReadFile[float64](path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if !reflect.DeepEqual(arr.Shape, []int{3, 3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", arr.Shape, []int{3, 3})
+	}
+	if expected := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+
+	// Mismatched rows and types are rejected
+	if err := AppendRowToFile(path, []float64{1, 2}); err == nil {
+		t.Error("Expected error for short row, got nil")
+	}
+	if err := AppendRowToFile(path, []int32{1, 2, 3}); err == nil {
+		t.Error("Expected error for mismatched type, got nil")
+	}
+}
+
+// TestAppendRowToFileRewrite tests appending when the header length changes
+func TestAppendRowToFileRewrite(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// A v2.0 file has a longer preamble than the v1.0 header written on append
+	dict := "{'descr': '<i4', 'fortran_order': False, 'shape': (1, 2), }"
+	data := binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, 10), 20)
+	raw := rawNPY(dict, data)
+	var v2 bytes.Buffer
+	v2.WriteString("\x93NUMPY\x02\x00")
+	binary.Write(&v2, binary.LittleEndian, uint32(len(raw)-10-len(data)))
+	v2.Write(raw[10:])
+
+	path := filepath.Join(tempDir, "v2.npy")
+	if err := os.WriteFile(path, v2.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := AppendRowToFile(path, []int32{30, 40}); err != nil {
+		t.Fatalf("Failed to append row: %v", err)
+	}
+
+	arr, err := ReadFile[int32](path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if !reflect.DeepEqual(arr.Shape, []int{2, 2}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", arr.Shape, []int{2, 2})
+	}
+	if expected := []int32{10, 20, 30, 40}; !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Size() != 80+16 {
+		t.Errorf("Expected a 96 byte file, got %d", info.Size())
+	}
+}
+
+// TestAppendRowToFileNumPyHeader tests appending to a file with NumPy's 64-byte aligned header
+func TestAppendRowToFileNumPyHeader(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Build a 2x2 float64 file with a 128 byte preamble, as NumPy writes it
+	dict := "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 2), }"
+	headerStr := dict + strings.Repeat(" ", 128-10-len(dict)-1) + "\n"
+	var raw bytes.Buffer
+	raw.WriteString("\x93NUMPY\x01\x00")
+	binary.Write(&raw, binary.LittleEndian, uint16(len(headerStr)))
+	raw.WriteString(headerStr)
+	for _, val := range []float64{1, 2, 3, 4} {
+		binary.Write(&raw, binary.LittleEndian, val)
+	}
+
+	path := filepath.Join(tempDir, "numpy.npy")
+	if err := os.WriteFile(path, raw.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := AppendRowToFile(path, []float64{5, 6}); err != nil {
+		t.Fatalf("Failed to append row: %v", err)
+	}
+
+	// The header keeps its length, so the data stays in place
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if len(contents) != 128+48 {
+		t.Errorf("Expected a %d byte file, got %d", 128+48, len(contents))
+	}
+	if !bytes.Equal(contents[128:160], raw.Bytes()[128:]) {
+		t.Errorf("Expected existing data to stay at offset 128")
+	}
+
+	arr, err := ReadFile[float64](path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if !reflect.DeepEqual(arr.Shape, []int{3, 2}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", arr.Shape, []int{3, 2})
+	}
+	if expected := []float64{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+}
@@ -0,0 +1,174 @@
+package npy
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// ReadAsFloat64 reads a NumPy array of any supported dtype from an io.Reader,
+// converting every element to float64 while preserving shape and order.
+// Booleans become 0 or 1.
+func ReadAsFloat64(r io.Reader) (*Array[float64], error) {
+	arr, err := readAny(r, ReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	converted, ok := toFloat64Array(arr)
+	if !ok {
+		return nil, fmt.Errorf("unsupported array type %T", arr)
+	}
+	return converted, nil
+}
+
+// AsFloat64Slice converts a boxed array, such as a GetAny result, to float64
+// data and its shape. The data keeps the array's memory order and booleans
+// become 0 or 1. It returns false if boxed is not a supported *Array[T].
+func AsFloat64Slice(boxed interface{}) ([]float64, []int, bool) {
+	converted, ok := toFloat64Array(boxed)
+	if !ok {
+		return nil, nil, false
+	}
+	return converted.Data, converted.Shape, true
+}
+
+// toFloat64Array converts an untyped array to float64, preserving shape and order
+func toFloat64Array(array interface{}) (*Array[float64], bool) {
+	switch arr := array.(type) {
+	case *Array[bool]:
+		data := make([]float64, len(arr.Data))
+		for i, val := range arr.Data {
+			if val {
+				data[i] = 1
+			}
+		}
+		return &Array[float64]{Data: data, Shape: append([]int(nil), arr.Shape...), DType: Float64, Fortran: arr.Fortran}, true
+	case *Array[int8]:
+		return numericToFloat64(arr), true
+	case *Array[int16]:
+		return numericToFloat64(arr), true
+	case *Array[int32]:
+		return numericToFloat64(arr), true
+	case *Array[int64]:
+		return numericToFloat64(arr), true
+	case *Array[uint8]:
+		return numericToFloat64(arr), true
+	case *Array[uint16]:
+		return numericToFloat64(arr), true
+	case *Array[uint32]:
+		return numericToFloat64(arr), true
+	case *Array[uint64]:
+		return numericToFloat64(arr), true
+	case *Array[float32]:
+		return numericToFloat64(arr), true
+	case *Array[float64]:
+		return numericToFloat64(arr), true
+	default:
+		return nil, false
+	}
+}
+
+// numericToFloat64 converts a numeric array to float64, preserving shape and order
+func numericToFloat64[T Numeric](a *Array[T]) *Array[float64] {
+	data := make([]float64, len(a.Data))
+	for i, val := range a.Data {
+		data[i] = float64(val)
+	}
+
+	return &Array[float64]{
+		Data:    data,
+		Shape:   append([]int(nil), a.Shape...),
+		DType:   Float64,
+		Fortran: a.Fortran,
+	}
+}
+
+// Astype converts a numeric array to element type To using Go's conversion
+// rules, preserving shape and order. Out-of-range values wrap or saturate
+// silently; use AstypeChecked to detect them.
+func Astype[To, From Numeric](a *Array[From]) *Array[To] {
+	data := make([]To, len(a.Data))
+	for i, val := range a.Data {
+		data[i] = To(val)
+	}
+
+	return &Array[To]{
+		Data:    data,
+		Shape:   append([]int(nil), a.Shape...),
+		DType:   dtypeOf[To](),
+		Fortran: a.Fortran,
+	}
+}
+
+// maxReportedIndices limits how many indices AstypeChecked lists in its error
+const maxReportedIndices = 10
+
+// AstypeChecked converts a numeric array like Astype, but returns an error
+// listing the indices of values that overflowed the target type or, for
+// integer sources, lost integer precision. Rounding float64 to float32 and
+// truncating floats toward zero are expected and not reported.
+func AstypeChecked[To, From Numeric](a *Array[From]) (*Array[To], error) {
+	out := Astype[To](a)
+
+	fromFloat, toFloat := isFloatType[From](), isFloatType[To]()
+	var bad []int
+	for i, val := range a.Data {
+		converted := out.Data[i]
+		var lossy bool
+		switch {
+		case fromFloat && toFloat:
+			lossy = !math.IsInf(float64(val), 0) && math.IsInf(float64(converted), 0)
+		case fromFloat:
+			f := float64(val)
+			lossy = math.IsNaN(f) || float64(From(converted)) != math.Trunc(f)
+		default:
+			lossy = From(converted) != val
+		}
+		if lossy {
+			bad = append(bad, i)
+		}
+	}
+
+	if len(bad) > 0 {
+		reported := bad
+		if len(reported) > maxReportedIndices {
+			reported = reported[:maxReportedIndices]
+		}
+		return nil, fmt.Errorf("converting %s to %s lost data at %d indices: %v", dtypeOf[From](), dtypeOf[To](), len(bad), reported)
+	}
+	return out, nil
+}
+
+// isFloatType reports whether T is a floating point type
+func isFloatType[T Numeric]() bool {
+	var zero T
+	switch any(zero).(type) {
+	case float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// CompactDType returns the int64 array converted to the smallest signed
+// integer type that holds every value, along with that type's DType. Go does
+// not allow methods on instantiated generic types, so this is a function
+// rather than a method. If no smaller type fits, a itself is returned.
+func CompactDType(a *Array[int64]) (interface{}, DType) {
+	lo, hi := int64(0), int64(0)
+	for _, val := range a.Data {
+		lo, hi = min(lo, val), max(hi, val)
+	}
+
+	switch {
+	case lo >= math.MinInt8 && hi <= math.MaxInt8:
+		return Astype[int8](a), Int8
+	case lo >= math.MinInt16 && hi <= math.MaxInt16:
+		return Astype[int16](a), Int16
+	case lo >= math.MinInt32 && hi <= math.MaxInt32:
+		return Astype[int32](a), Int32
+	default:
+		return a, Int64
+	}
+}
@@ -0,0 +1,221 @@
+package npy
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestReadAsFloat64 tests reading integer, float and bool files as float64
+func TestReadAsFloat64(t *testing.T) {
+	// Create test arrays of different dtypes
+	int32Arr := &Array[int32]{
+		Data:    []int32{-1, 0, 1, 2, 3, 4},
+		Shape:   []int{2, 3},
+		DType:   Int32,
+		Fortran: true,
+	}
+	float32Arr := &Array[float32]{
+		Data:    []float32{0.5, 1.25, -2.75},
+		Shape:   []int{3},
+		DType:   Float32,
+		Fortran: false,
+	}
+	boolArr := &Array[bool]{
+		Data:    []bool{true, false},
+		Shape:   []int{2},
+		DType:   Bool,
+		Fortran: false,
+	}
+
+	tests := []struct {
+		name    string
+		write   func(*bytes.Buffer) error
+		data    []float64
+		shape   []int
+		fortran bool
+	}{
+		{"int32", func(b *bytes.Buffer) error { return Write(b, int32Arr) }, []float64{-1, 0, 1, 2, 3, 4}, []int{2, 3}, true},
+		{"float32", func(b *bytes.Buffer) error { return Write(b, float32Arr) }, []float64{0.5, 1.25, -2.75}, []int{3}, false},
+		{"bool", func(b *bytes.Buffer) error { return Write(b, boolArr) }, []float64{1, 0}, []int{2}, false},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := tt.write(&buf); err != nil {
+			t.Fatalf("Failed to write %s array: %v", tt.name, err)
+		}
+
+		readArr, err := ReadAsFloat64(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("Failed to read %s array as float64: %v", tt.name, err)
+		}
+
+		// Verify data, shape, dtype and order
+		if !reflect.DeepEqual(readArr.Data, tt.data) {
+			t.Errorf("Data mismatch for %s. Got %v, want %v", tt.name, readArr.Data, tt.data)
+		}
+		if !reflect.DeepEqual(readArr.Shape, tt.shape) {
+			t.Errorf("Shape mismatch for %s. Got %v, want %v", tt.name, readArr.Shape, tt.shape)
+		}
+		if readArr.DType != Float64 {
+			t.Errorf("DType mismatch for %s. Got %v, want %v", tt.name, readArr.DType, Float64)
+		}
+		if readArr.Fortran != tt.fortran {
+			t.Errorf("Fortran order mismatch for %s. Got %v, want %v", tt.name, readArr.Fortran, tt.fortran)
+		}
+	}
+}
+
+// TestAstypeChecked tests that lossy conversions are reported
+func TestAstypeChecked(t *testing.T) {
+	// Create test array with one value above the int32 maximum
+	arr := &Array[int64]{
+		Data:    []int64{1, math.MaxInt32 + 1, -5},
+		Shape:   []int{3},
+		DType:   Int64,
+		Fortran: false,
+	}
+
+	// The unchecked conversion wraps silently
+	wrapped := Astype[int32](arr)
+	if wrapped.DType != Int32 {
+		t.Errorf("DType mismatch. Got %v, want %v", wrapped.DType, Int32)
+	}
+	if wrapped.Data[1] != math.MinInt32 {
+		t.Errorf("Expected wrapped value %d, got %d", math.MinInt32, wrapped.Data[1])
+	}
+
+	// The checked conversion reports the index
+	if _, err := AstypeChecked[int32](arr); err == nil {
+		t.Fatal("Expected error for out-of-range value, got nil")
+	} else if !strings.Contains(err.Error(), "[1]") {
+		t.Errorf("Expected error to list index 1, got %v", err)
+	}
+
+	// In-range values convert without error
+	arr.Data[1] = 7
+	converted, err := AstypeChecked[int32](arr)
+	if err != nil {
+		t.Fatalf("Failed to convert in-range values: %v", err)
+	}
+	if expected := []int32{1, 7, -5}; !reflect.DeepEqual(converted.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", converted.Data, expected)
+	}
+
+	// Integers too large for float32 lose precision
+	big := &Array[int32]{Data: []int32{1 << 24, 1<<24 + 1}, Shape: []int{2}, DType: Int32}
+	if _, err := AstypeChecked[float32](big); err == nil || !strings.Contains(err.Error(), "[1]") {
+		t.Errorf("Expected precision loss at index 1, got %v", err)
+	}
+
+	// Float rounding and truncation are expected, but overflow and NaN are not
+	floats := &Array[float64]{Data: []float64{0.1, 2.9}, Shape: []int{2}, DType: Float64}
+	if _, err := AstypeChecked[float32](floats); err != nil {
+		t.Errorf("Unexpected error narrowing floats: %v", err)
+	}
+	if _, err := AstypeChecked[int8](floats); err != nil {
+		t.Errorf("Unexpected error truncating floats: %v", err)
+	}
+	floats.Data = []float64{1e300, math.NaN()}
+	if _, err := AstypeChecked[float32](floats); err == nil || !strings.Contains(err.Error(), "[0]") {
+		t.Errorf("Expected overflow at index 0, got %v", err)
+	}
+	if _, err := AstypeChecked[int8](floats); err == nil || !strings.Contains(err.Error(), "[0 1]") {
+		t.Errorf("Expected overflow and NaN at indices 0 and 1, got %v", err)
+	}
+}
+
+// TestCompactDType tests shrinking int64 arrays to the smallest fitting type
+func TestCompactDType(t *testing.T) {
+	// Create test array whose values fit int8
+	arr := &Array[int64]{
+		Data:    []int64{-128, 0, 127},
+		Shape:   []int{3},
+		DType:   Int64,
+		Fortran: false,
+	}
+
+	compact, dtype := CompactDType(arr)
+	if dtype != Int8 {
+		t.Errorf("DType mismatch. Got %v, want %v", dtype, Int8)
+	}
+	small, ok := compact.(*Array[int8])
+	if !ok {
+		t.Fatalf("Expected *Array[int8], got %T", compact)
+	}
+	if expected := []int8{-128, 0, 127}; !reflect.DeepEqual(small.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", small.Data, expected)
+	}
+	if small.DType != Int8 {
+		t.Errorf("Array DType mismatch. Got %v, want %v", small.DType, Int8)
+	}
+
+	// Wider ranges pick wider types
+	arr.Data = []int64{0, 40000}
+	if _, dtype := CompactDType(arr); dtype != Int32 {
+		t.Errorf("DType mismatch. Got %v, want %v", dtype, Int32)
+	}
+	arr.Data = []int64{math.MinInt64}
+	if compact, dtype := CompactDType(arr); dtype != Int64 || compact != arr {
+		t.Errorf("Expected the original int64 array, got %T %v", compact, dtype)
+	}
+}
+
+// TestAsFloat64Slice tests converting boxed arrays from GetAny to float64
+func TestAsFloat64Slice(t *testing.T) {
+	npz := NewNPZFile()
+	Add(npz, "counts", &Array[int16]{
+		Data:  []int16{-3, 0, 7, 32767},
+		Shape: []int{2, 2},
+		DType: Int16,
+	})
+
+	boxed, ok := GetAny(npz, "counts.npy")
+	if !ok {
+		t.Fatal("Expected GetAny to find counts")
+	}
+	data, shape, ok := AsFloat64Slice(boxed)
+	if !ok {
+		t.Fatalf("Expected conversion of %T to succeed", boxed)
+	}
+	if expected := []float64{-3, 0, 7, 32767}; !reflect.DeepEqual(data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", data, expected)
+	}
+	if !reflect.DeepEqual(shape, []int{2, 2}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", shape, []int{2, 2})
+	}
+
+	// Missing entries and non-array values are reported
+	if _, ok := GetAny(npz, "missing"); ok {
+		t.Error("Expected GetAny to fail for missing entry")
+	}
+	if _, _, ok := AsFloat64Slice([]int16{1}); ok {
+		t.Error("Expected conversion of a plain slice to fail")
+	}
+}
+
+// TestConversionsCopyShape tests that converted arrays don't share the source's shape
+func TestConversionsCopyShape(t *testing.T) {
+	src := &Array[int64]{Data: []int64{1, 2, 3, 4}, Shape: []int{2, 2}, DType: Int64}
+
+	b := Astype[int32](src)
+	b.Shape[0] = 99
+	compact, _ := CompactDType(src)
+	compact.(*Array[int8]).Shape[0] = 99
+	_, shape, _ := AsFloat64Slice(src)
+	shape[0] = 99
+
+	flags := &Array[bool]{Data: []bool{true, false}, Shape: []int{2}, DType: Bool}
+	_, flagShape, _ := AsFloat64Slice(flags)
+	flagShape[0] = 99
+
+	if !reflect.DeepEqual(src.Shape, []int{2, 2}) {
+		t.Errorf("Source shape was modified. Got %v, want %v", src.Shape, []int{2, 2})
+	}
+	if !reflect.DeepEqual(flags.Shape, []int{2}) {
+		t.Errorf("Source shape was modified. Got %v, want %v", flags.Shape, []int{2})
+	}
+}
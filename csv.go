@@ -1,14 +1,71 @@
 package npy
 
 import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
 	"encoding/csv"
 	"fmt"
+	"io"
+	"math"
 	"os"
-	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// FloatFormat selects how floating point values are written to CSV
+type FloatFormat int
+
+const (
+	// FloatDefault formats floats with Go's %v verb
+	FloatDefault FloatFormat = iota
+	// FloatFixed formats floats with CsvOptions.Decimals decimal places
+	FloatFixed
+	// FloatTrimIntegral writes whole numbers without a decimal point and
+	// other values in their shortest form
+	FloatTrimIntegral
+)
+
+// QuoteMode selects when non-numeric CSV fields are enclosed in quotes
+type QuoteMode int
+
+const (
+	// QuoteMinimal quotes fields only when they contain the separator, a
+	// quote or a line break, as encoding/csv does
+	QuoteMinimal QuoteMode = iota
+	// QuoteAll quotes every field
+	QuoteAll
+	// QuoteNever writes every field verbatim
+	QuoteNever
+)
+
+// CsvOptions controls how arrays are formatted when exported to CSV
+type CsvOptions struct {
+	UseCRLF     bool        // Terminate rows with \r\n instead of \n
+	BoolAsInt   bool        // Write booleans as 1 and 0 instead of true and false
+	FloatFormat FloatFormat // How to format float32 and float64 values
+	Decimals    int         // Decimal places used by FloatFixed
+	Comma       rune        // Field separator; defaults to ','. Numeric and bool data reject letters, digits and +-.
+	Quote       QuoteMode   // When to quote fields; numeric and bool fields are never quoted
+
+	// Scientific writes every float in exponent notation, such as 1.5e+00,
+	// using the shortest precision that round-trips. It overrides FloatFormat.
+	Scientific bool
+
+	// Compress gzips the output. NPZToCsvDirWithOptions then names its files
+	// with a .csv.gz extension.
+	Compress bool
+}
+
 // ToCsv exports an array to a CSV file
 func ToCsv[T any](arr *Array[T], csvPath string) error {
+	return ToCsvWithOptions(arr, csvPath, CsvOptions{})
+}
+
+// ToCsvWithOptions exports an array to a CSV file using the given options
+func ToCsvWithOptions[T any](arr *Array[T], csvPath string, opts CsvOptions) error {
 	// Create the file
 	f, err := os.Create(csvPath)
 	if err != nil {
@@ -16,9 +73,40 @@ func ToCsv[T any](arr *Array[T], csvPath string) error {
 	}
 	defer f.Close()
 
-	// Create a CSV writer
-	writer := csv.NewWriter(f)
-	defer writer.Flush()
+	// Compress the output if requested
+	if !opts.Compress {
+		return writeCsv(f, arr, opts)
+	}
+	gz := gzip.NewWriter(f)
+	if err := writeCsv(gz, arr, opts); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish gzip stream: %w", err)
+	}
+	return nil
+}
+
+// ToCsvGz exports an array to a gzip-compressed CSV file using the given options
+func ToCsvGz[T any](arr *Array[T], path string, opts CsvOptions) error {
+	opts.Compress = true
+	return ToCsvWithOptions(arr, path, opts)
+}
+
+// writeCsv writes an array as CSV to w
+func writeCsv[T any](w io.Writer, arr *Array[T], opts CsvOptions) (err error) {
+	// Create a CSV writer; numbers and bools never need quoting
+	writer, err := newCsvWriter(w, opts, dtypeOf[T]() == "")
+	if err != nil {
+		return err
+	}
+
+	// A failed final flush would leave the output truncated, so report it
+	defer func() {
+		if flushErr := writer.Flush(); flushErr != nil && err == nil {
+			err = fmt.Errorf("failed to write CSV data: %w", flushErr)
+		}
+	}()
 
 	// Handle the data based on dimensions
 	dimensions := len(arr.Shape)
@@ -30,7 +118,7 @@ func ToCsv[T any](arr *Array[T], csvPath string) error {
 		// 1D array (vector) - write as a single row
 		record := make([]string, len(arr.Data))
 		for i, val := range arr.Data {
-			record[i] = fmt.Sprintf("%v", val)
+			record[i] = formatCsvValue(val, opts)
 		}
 		if err := writer.Write(record); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
@@ -44,15 +132,8 @@ func ToCsv[T any](arr *Array[T], csvPath string) error {
 			record := make([]string, cols)
 			for c := 0; c < cols; c++ {
 				// Calculate index based on ordering
-				var idx int
-				if arr.Fortran {
-					// Column-major (Fortran) order
-					idx = c*rows + r
-				} else {
-					// Row-major (C) order
-					idx = r*cols + c
-				}
-				record[c] = fmt.Sprintf("%v", arr.Data[idx])
+				idx := ravelIndex([]int{r, c}, arr.Shape, arr.Fortran)
+				record[c] = formatCsvValue(arr.Data[idx], opts)
 			}
 			if err := writer.Write(record); err != nil {
 				return fmt.Errorf("failed to write CSV row: %w", err)
@@ -66,8 +147,128 @@ func ToCsv[T any](arr *Array[T], csvPath string) error {
 	return nil
 }
 
-// NPZToCsvDir exports all arrays in an NPZ file to CSV files in the specified directory
+// formatCsvValue formats a single array element for CSV output
+func formatCsvValue[T any](val T, opts CsvOptions) string {
+	switch v := any(val).(type) {
+	case bool:
+		if opts.BoolAsInt {
+			if v {
+				return "1"
+			}
+			return "0"
+		}
+	case float32:
+		return formatCsvFloat(float64(v), 32, opts)
+	case float64:
+		return formatCsvFloat(v, 64, opts)
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+// formatCsvFloat formats a floating point value of the given bit size for CSV output
+func formatCsvFloat(v float64, bitSize int, opts CsvOptions) string {
+	if opts.Scientific {
+		return strconv.FormatFloat(v, 'e', -1, bitSize)
+	}
+
+	switch opts.FloatFormat {
+	case FloatFixed:
+		return strconv.FormatFloat(v, 'f', opts.Decimals, bitSize)
+	case FloatTrimIntegral:
+		if v == math.Trunc(v) && math.Abs(v) < 1e21 {
+			return strconv.FormatFloat(v, 'f', 0, bitSize)
+		}
+	}
+	return strconv.FormatFloat(v, 'g', -1, bitSize)
+}
+
+// csvWriter writes CSV records, quoting fields as selected by CsvOptions
+type csvWriter struct {
+	w     *bufio.Writer
+	opts  CsvOptions
+	comma rune
+	quote bool // Whether fields may be quoted at all
+}
+
+// newCsvWriter creates a csvWriter, validating the configured separator.
+// Fields that are never quoted must not be able to contain the separator, so
+// characters that appear in formatted numbers and bools are rejected for them.
+func newCsvWriter(w io.Writer, opts CsvOptions, quote bool) (*csvWriter, error) {
+	comma := opts.Comma
+	if comma == 0 {
+		comma = ','
+	}
+	if comma == '"' || comma == '\r' || comma == '\n' || comma == utf8.RuneError {
+		return nil, fmt.Errorf("invalid CSV separator: %q", comma)
+	}
+	if !quote && (unicode.IsDigit(comma) || unicode.IsLetter(comma) || strings.ContainsRune("+-.", comma)) {
+		return nil, fmt.Errorf("invalid CSV separator for numeric data: %q", comma)
+	}
+
+	return &csvWriter{
+		w:     bufio.NewWriter(w),
+		opts:  opts,
+		comma: comma,
+		quote: quote,
+	}, nil
+}
+
+// Write writes a single record followed by a line terminator
+func (cw *csvWriter) Write(record []string) error {
+	for i, field := range record {
+		if i > 0 {
+			cw.w.WriteRune(cw.comma)
+		}
+		if cw.quote && cw.needsQuotes(field) {
+			cw.w.WriteByte('"')
+			cw.w.WriteString(strings.ReplaceAll(field, `"`, `""`))
+			cw.w.WriteByte('"')
+		} else {
+			cw.w.WriteString(field)
+		}
+	}
+
+	lineEnd := "\n"
+	if cw.opts.UseCRLF {
+		lineEnd = "\r\n"
+	}
+	_, err := cw.w.WriteString(lineEnd)
+	return err
+}
+
+// Flush writes any buffered data to the underlying writer
+func (cw *csvWriter) Flush() error {
+	return cw.w.Flush()
+}
+
+// needsQuotes reports whether field must be quoted under the configured mode
+func (cw *csvWriter) needsQuotes(field string) bool {
+	switch cw.opts.Quote {
+	case QuoteAll:
+		return true
+	case QuoteNever:
+		return false
+	}
+
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, cw.comma) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
+}
+
+// NPZToCsvDir exports all arrays in an NPZ file to CSV files in the specified
+// directory. An archive with no arrays leaves the directory created but empty.
 func NPZToCsvDir(npzPath string, outputDir string) error {
+	return NPZToCsvDirWithOptions(npzPath, outputDir, CsvOptions{})
+}
+
+// NPZToCsvDirWithOptions exports all arrays in an NPZ file to CSV files in the
+// specified directory using the given options
+func NPZToCsvDirWithOptions(npzPath string, outputDir string, opts CsvOptions) error {
 	// Read the NPZ file
 	npz, err := ReadNPZFile(npzPath)
 	if err != nil {
@@ -79,114 +280,236 @@ func NPZToCsvDir(npzPath string, outputDir string) error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	ext := ".csv"
+	if opts.Compress {
+		ext = ".csv.gz"
+	}
+
 	// Export each array based on its type
 	for _, key := range Keys(npz) {
-		outPath := filepath.Join(outputDir, key+".csv")
+		outPath, err := entryOutputPath(outputDir, key, ext)
+		if err != nil {
+			return err
+		}
+		if err := toCsvAny(npz.arrays[key], outPath, opts); err != nil {
+			return fmt.Errorf("failed to export %s: %w", key, err)
+		}
+	}
 
-		// Here we need to try each type due to Go's type system limitations
-		exported := false
+	return nil
+}
 
-		if arr, ok := Get[bool](npz, key); ok {
-			if err := ToCsv(arr, outPath); err != nil {
-				return fmt.Errorf("failed to export %s: %w", key, err)
-			}
-			exported = true
-		}
+// toCsvAny exports an untyped array to a CSV file, dispatching on its element type
+func toCsvAny(array interface{}, csvPath string, opts CsvOptions) error {
+	switch arr := array.(type) {
+	case *Array[bool]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[int8]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[int16]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[int32]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[int64]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[uint8]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[uint16]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[uint32]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[uint64]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[float32]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	case *Array[float64]:
+		return ToCsvWithOptions(arr, csvPath, opts)
+	default:
+		return fmt.Errorf("unsupported data type %T", array)
+	}
+}
 
-		if !exported {
-			if arr, ok := Get[int8](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
-			}
-		}
+// FromCsv reads a CSV file of numbers into a 2D float64 array with one row
+// per CSV record. An empty file yields an empty 1D array.
+func FromCsv(csvPath string) (*Array[float64], error) {
+	f, err := os.Open(csvPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer f.Close()
 
-		if !exported {
-			if arr, ok := Get[int16](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
-			}
-		}
+	// Read all records; the reader enforces a consistent column count
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV file: %w", err)
+	}
+	if len(records) == 0 {
+		return &Array[float64]{Data: []float64{}, Shape: []int{0}, DType: Float64}, nil
+	}
 
-		if !exported {
-			if arr, ok := Get[int32](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
+	rows, cols := len(records), len(records[0])
+	data := make([]float64, 0, rows*cols)
+	for r, record := range records {
+		for c, field := range record {
+			val, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number at row %d, column %d: %q", r+1, c+1, field)
 			}
+			data = append(data, val)
 		}
+	}
 
-		if !exported {
-			if arr, ok := Get[int64](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
-			}
-		}
+	return &Array[float64]{
+		Data:  data,
+		Shape: []int{rows, cols},
+		DType: Float64,
+	}, nil
+}
 
-		if !exported {
-			if arr, ok := Get[uint8](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
-			}
-		}
+// CsvToNpyStream converts a numeric CSV file with cols columns to a 2D .npy
+// file of the given dtype, one row at a time, so memory use does not grow
+// with the file. A header with room for any row count is written first and
+// patched with the final count once all rows are converted.
+func CsvToNpyStream(csvPath, npyPath string, dtype DType, cols int) error {
+	if cols <= 0 {
+		return fmt.Errorf("invalid column count: %d", cols)
+	}
 
-		if !exported {
-			if arr, ok := Get[uint16](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
-			}
-		}
+	switch dtype {
+	case Int8:
+		return csvToNpyStream[int8](csvPath, npyPath, cols)
+	case Int16:
+		return csvToNpyStream[int16](csvPath, npyPath, cols)
+	case Int32:
+		return csvToNpyStream[int32](csvPath, npyPath, cols)
+	case Int64:
+		return csvToNpyStream[int64](csvPath, npyPath, cols)
+	case Uint8:
+		return csvToNpyStream[uint8](csvPath, npyPath, cols)
+	case Uint16:
+		return csvToNpyStream[uint16](csvPath, npyPath, cols)
+	case Uint32:
+		return csvToNpyStream[uint32](csvPath, npyPath, cols)
+	case Uint64:
+		return csvToNpyStream[uint64](csvPath, npyPath, cols)
+	case Float32:
+		return csvToNpyStream[float32](csvPath, npyPath, cols)
+	case Float64:
+		return csvToNpyStream[float64](csvPath, npyPath, cols)
+	default:
+		return fmt.Errorf("unsupported dtype for CSV conversion: %s", dtype)
+	}
+}
 
-		if !exported {
-			if arr, ok := Get[uint32](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
-			}
-		}
+// csvToNpyStream implements CsvToNpyStream for element type T
+func csvToNpyStream[T Numeric](csvPath, npyPath string, cols int) error {
+	in, err := os.Open(csvPath)
+	if err != nil {
+		return fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer in.Close()
 
-		if !exported {
-			if arr, ok := Get[uint64](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
-			}
-		}
+	out, err := os.Create(npyPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer out.Close()
 
-		if !exported {
-			if arr, ok := Get[float32](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
-			}
-		}
+	// Reserve a header long enough for the largest possible row count
+	placeholder := csvStreamPreamble[T](math.MaxInt, cols, 0)
+	w := bufio.NewWriter(out)
+	if _, err := w.Write(placeholder); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
 
-		if !exported {
-			if arr, ok := Get[float64](npz, key); ok {
-				if err := ToCsv(arr, outPath); err != nil {
-					return fmt.Errorf("failed to export %s: %w", key, err)
-				}
-				exported = true
+	// Convert rows; the reader enforces the column count
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = cols
+	reader.ReuseRecord = true
+	row := make([]T, cols)
+	rows := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read CSV file: %w", err)
+		}
+		for c, field := range record {
+			if row[c], err = parseCsvNumber[T](strings.TrimSpace(field)); err != nil {
+				return fmt.Errorf("invalid number at row %d, column %d: %q", rows+1, c+1, field)
 			}
 		}
-
-		if !exported {
-			return fmt.Errorf("unsupported data type for array %s", key)
+		if err := writeData(w, binary.LittleEndian, row); err != nil {
+			return fmt.Errorf("failed to write row %d: %w", rows+1, err)
 		}
+		rows++
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write data: %w", err)
 	}
 
-	return nil
+	// Patch the real row count into the reserved header
+	if _, err := out.WriteAt(csvStreamPreamble[T](rows, cols, len(placeholder)), 0); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	return out.Close()
+}
+
+// csvStreamPreamble encodes the v1.0 preamble for a rows x cols C-order array
+// of T. If size is positive the header is padded so the preamble is exactly
+// size bytes.
+func csvStreamPreamble[T Numeric](rows, cols, size int) []byte {
+	headerStr := generateHeader(&Array[T]{Shape: []int{rows, cols}, DType: dtypeOf[T]()}, binary.LittleEndian)
+	if size > 0 {
+		headerStr = padHeaderTo(headerStr, size-10)
+	} else {
+		headerStr = padHeader(headerStr)
+	}
+
+	preamble := make([]byte, 0, 10+len(headerStr))
+	preamble = append(preamble, "\x93NUMPY\x01\x00"...)
+	preamble = binary.LittleEndian.AppendUint16(preamble, uint16(len(headerStr)))
+	return append(preamble, headerStr...)
+}
+
+// parseCsvNumber parses a CSV field as T, rejecting values that don't fit
+func parseCsvNumber[T Numeric](field string) (T, error) {
+	dtype := dtypeOf[T]()
+	bits := dtype.ItemSize() * 8
+	switch dtype {
+	case Float32, Float64:
+		v, err := strconv.ParseFloat(field, bits)
+		return T(v), err
+	case Uint8, Uint16, Uint32, Uint64:
+		v, err := strconv.ParseUint(field, 10, bits)
+		return T(v), err
+	default:
+		v, err := strconv.ParseInt(field, 10, bits)
+		return T(v), err
+	}
+}
+
+// ConvertFile converts between formats based on the file extensions:
+// .npy to .csv, .npz to a directory of CSV files, and .csv to .npy
+func ConvertFile(inPath, outPath string) error {
+	switch {
+	case strings.HasSuffix(inPath, ".npy") && strings.HasSuffix(outPath, ".csv"):
+		arr, err := readAnyFile(inPath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", inPath, err)
+		}
+		return toCsvAny(arr, outPath, CsvOptions{})
+	case strings.HasSuffix(inPath, ".npz"):
+		return NPZToCsvDir(inPath, outPath)
+	case strings.HasSuffix(inPath, ".csv") && strings.HasSuffix(outPath, ".npy"):
+		arr, err := FromCsv(inPath)
+		if err != nil {
+			return err
+		}
+		return WriteFile(outPath, arr)
+	default:
+		return fmt.Errorf("unsupported conversion from %s to %s", inPath, outPath)
+	}
 }
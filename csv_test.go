@@ -1,7 +1,10 @@
 package npy
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/csv"
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -276,3 +279,479 @@ func TestNPZToCsvDir(t *testing.T) {
 		t.Errorf("Unexpected dimensions for array2.csv: %d x %d", len(records2), len(records2[0]))
 	}
 }
+
+// TestToCsv_UseCRLF tests that the UseCRLF option controls the row terminator
+func TestToCsv_UseCRLF(t *testing.T) {
+	// Create test array - 2x2 matrix
+	arr := &Array[int32]{
+		Data:    []int32{1, 2, 3, 4},
+		Shape:   []int{2, 2},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-csv-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Export with the default line ending
+	lfPath := filepath.Join(tempDir, "test_lf.csv")
+	if err := ToCsv(arr, lfPath); err != nil {
+		t.Fatalf("Failed to export to Csv: %v", err)
+	}
+	lfBytes, err := os.ReadFile(lfPath)
+	if err != nil {
+		t.Fatalf("Failed to read Csv file: %v", err)
+	}
+	if string(lfBytes) != "1,2\n3,4\n" {
+		t.Errorf("Unexpected LF output. Got %q", lfBytes)
+	}
+
+	// Export with CRLF line endings
+	crlfPath := filepath.Join(tempDir, "test_crlf.csv")
+	if err := ToCsvWithOptions(arr, crlfPath, CsvOptions{UseCRLF: true}); err != nil {
+		t.Fatalf("Failed to export to Csv: %v", err)
+	}
+	crlfBytes, err := os.ReadFile(crlfPath)
+	if err != nil {
+		t.Fatalf("Failed to read Csv file: %v", err)
+	}
+	if string(crlfBytes) != "1,2\r\n3,4\r\n" {
+		t.Errorf("Unexpected CRLF output. Got %q", crlfBytes)
+	}
+
+	// Export an NPZ file with CRLF line endings
+	npz := NewNPZFile()
+	Add(npz, "matrix", arr)
+	npzPath := filepath.Join(tempDir, "test.npz")
+	if err := WriteNPZFile(npzPath, npz); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+	csvDir := filepath.Join(tempDir, "csv")
+	if err := NPZToCsvDirWithOptions(npzPath, csvDir, CsvOptions{UseCRLF: true}); err != nil {
+		t.Fatalf("Failed to export NPZ to Csv: %v", err)
+	}
+	npzBytes, err := os.ReadFile(filepath.Join(csvDir, "matrix.csv"))
+	if err != nil {
+		t.Fatalf("Failed to read matrix.csv: %v", err)
+	}
+	if !bytes.Contains(npzBytes, []byte("\r\n")) {
+		t.Errorf("Expected CRLF line endings in NPZ export, got %q", npzBytes)
+	}
+}
+
+// TestConvertFile tests converting between .npy and .csv files
+func TestConvertFile(t *testing.T) {
+	// Create test array - 2x3 matrix
+	arr := &Array[int16]{
+		Data:    []int16{1, 2, 3, 4, 5, 6},
+		Shape:   []int{2, 3},
+		DType:   Int16,
+		Fortran: false,
+	}
+
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-csv-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	npyPath := filepath.Join(tempDir, "input.npy")
+	if err := WriteFile(npyPath, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+
+	// Convert npy to csv without knowing the element type
+	csvPath := filepath.Join(tempDir, "output.csv")
+	if err := ConvertFile(npyPath, csvPath); err != nil {
+		t.Fatalf("Failed to convert npy to csv: %v", err)
+	}
+	csvBytes, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("Failed to read Csv file: %v", err)
+	}
+	if string(csvBytes) != "1,2,3\n4,5,6\n" {
+		t.Errorf("Unexpected Csv output. Got %q", csvBytes)
+	}
+
+	// Convert csv back to npy
+	roundTripPath := filepath.Join(tempDir, "roundtrip.npy")
+	if err := ConvertFile(csvPath, roundTripPath); err != nil {
+		t.Fatalf("Failed to convert csv to npy: %v", err)
+	}
+	readArr, err := ReadFile[float64](roundTripPath)
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	expected := []float64{1, 2, 3, 4, 5, 6}
+	if !reflect.DeepEqual(readArr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, expected)
+	}
+	if !reflect.DeepEqual(readArr.Shape, arr.Shape) {
+		t.Errorf("Shape mismatch. Got %v, want %v", readArr.Shape, arr.Shape)
+	}
+
+	// Verify unsupported conversions are rejected
+	if err := ConvertFile(npyPath, filepath.Join(tempDir, "output.txt")); err == nil {
+		t.Error("Expected error for unsupported conversion, got nil")
+	}
+}
+
+// TestToCsv_BoolAsInt tests exporting a bool array as words and as integers
+func TestToCsv_BoolAsInt(t *testing.T) {
+	// Create test array
+	arr := &Array[bool]{
+		Data:    []bool{true, false, false, true},
+		Shape:   []int{2, 2},
+		DType:   Bool,
+		Fortran: false,
+	}
+
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-csv-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name     string
+		opts     CsvOptions
+		expected string
+	}{
+		{"words", CsvOptions{}, "true,false\nfalse,true\n"},
+		{"ints", CsvOptions{BoolAsInt: true}, "1,0\n0,1\n"},
+	}
+
+	for _, tt := range tests {
+		// Export a single array
+		csvPath := filepath.Join(tempDir, tt.name+".csv")
+		if err := ToCsvWithOptions(arr, csvPath, tt.opts); err != nil {
+			t.Fatalf("Failed to export to Csv: %v", err)
+		}
+		got, err := os.ReadFile(csvPath)
+		if err != nil {
+			t.Fatalf("Failed to read Csv file: %v", err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("Unexpected %s output. Got %q, want %q", tt.name, got, tt.expected)
+		}
+
+		// Export via an NPZ file and verify the output matches
+		npz := NewNPZFile()
+		Add(npz, "mask", arr)
+		npzPath := filepath.Join(tempDir, tt.name+".npz")
+		if err := WriteNPZFile(npzPath, npz); err != nil {
+			t.Fatalf("Failed to write NPZ file: %v", err)
+		}
+		csvDir := filepath.Join(tempDir, tt.name)
+		if err := NPZToCsvDirWithOptions(npzPath, csvDir, tt.opts); err != nil {
+			t.Fatalf("Failed to export NPZ to Csv: %v", err)
+		}
+		got, err = os.ReadFile(filepath.Join(csvDir, "mask.csv"))
+		if err != nil {
+			t.Fatalf("Failed to read mask.csv: %v", err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("Unexpected %s NPZ output. Got %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+// TestToCsv_FloatFormat tests fixed-decimal and integral float formatting
+func TestToCsv_FloatFormat(t *testing.T) {
+	// Create test array
+	arr := &Array[float64]{
+		Data:    []float64{1.0, 2.5},
+		Shape:   []int{2},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-csv-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name     string
+		opts     CsvOptions
+		expected string
+	}{
+		{"fixed", CsvOptions{FloatFormat: FloatFixed, Decimals: 2}, "1.00,2.50\n"},
+		{"integral", CsvOptions{FloatFormat: FloatTrimIntegral}, "1,2.5\n"},
+	}
+
+	for _, tt := range tests {
+		csvPath := filepath.Join(tempDir, tt.name+".csv")
+		if err := ToCsvWithOptions(arr, csvPath, tt.opts); err != nil {
+			t.Fatalf("Failed to export to Csv: %v", err)
+		}
+		got, err := os.ReadFile(csvPath)
+		if err != nil {
+			t.Fatalf("Failed to read Csv file: %v", err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("Unexpected %s output. Got %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+// TestToCsv_Quoting tests quoting modes and that numeric fields are never quoted
+func TestToCsv_Quoting(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-csv-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// String values containing separators, quotes and leading spaces
+	strs := &Array[string]{
+		Data:  []string{"a,b", `say "hi"`, " padded", "plain"},
+		Shape: []int{2, 2},
+	}
+
+	// Numeric fields are never quoted, even under QuoteAll
+	floats := &Array[float64]{
+		Data:  []float64{-1.5, 2, -3},
+		Shape: []int{3},
+		DType: Float64,
+	}
+
+	tests := []struct {
+		name     string
+		write    func(path string) error
+		expected string
+	}{
+		{"minimal", func(p string) error { return ToCsvWithOptions(strs, p, CsvOptions{}) },
+			"\"a,b\",\"say \"\"hi\"\"\"\n\" padded\",plain\n"},
+		{"all", func(p string) error { return ToCsvWithOptions(strs, p, CsvOptions{Quote: QuoteAll}) },
+			"\"a,b\",\"say \"\"hi\"\"\"\n\" padded\",\"plain\"\n"},
+		{"never", func(p string) error { return ToCsvWithOptions(strs, p, CsvOptions{Quote: QuoteNever}) },
+			"a,b,say \"hi\"\n padded,plain\n"},
+		{"semicolon", func(p string) error { return ToCsvWithOptions(strs, p, CsvOptions{Comma: ';'}) },
+			"a,b;\"say \"\"hi\"\"\"\n\" padded\";plain\n"},
+		{"numeric_all", func(p string) error { return ToCsvWithOptions(floats, p, CsvOptions{Quote: QuoteAll}) },
+			"-1.5,2,-3\n"},
+	}
+
+	for _, tt := range tests {
+		csvPath := filepath.Join(tempDir, tt.name+".csv")
+		if err := tt.write(csvPath); err != nil {
+			t.Fatalf("Failed to export %s to Csv: %v", tt.name, err)
+		}
+		got, err := os.ReadFile(csvPath)
+		if err != nil {
+			t.Fatalf("Failed to read Csv file: %v", err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("Unexpected %s output. Got %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+
+	// Quotes can't be used as the separator
+	if err := ToCsvWithOptions(floats, filepath.Join(tempDir, "bad.csv"), CsvOptions{Comma: '"'}); err == nil {
+		t.Errorf("Expected error for quote separator")
+	}
+
+	// Numbers are never quoted, so separators that appear in them are rejected
+	for _, comma := range []rune{'-', '+', '.', 'e', 'E', '5'} {
+		if err := ToCsvWithOptions(floats, filepath.Join(tempDir, "bad.csv"), CsvOptions{Comma: comma}); err == nil {
+			t.Errorf("Expected error for %q separator with numeric data", comma)
+		}
+	}
+	if err := ToCsvWithOptions(strs, filepath.Join(tempDir, "dash.csv"), CsvOptions{Comma: '-'}); err != nil {
+		t.Errorf("Expected '-' separator to be allowed for strings, got %v", err)
+	}
+}
+
+// failingWriter is an io.Writer that always fails
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestToCsv_FlushError tests that a failed final write is reported
+func TestToCsv_FlushError(t *testing.T) {
+	arr := &Array[int32]{Data: []int32{1, 2, 3}, Shape: []int{3}, DType: Int32}
+
+	// The row fits in the buffer, so the failure only surfaces on flush
+	if err := writeCsv(failingWriter{}, arr, CsvOptions{}); err == nil {
+		t.Error("Expected error from failed flush, got nil")
+	}
+}
+
+// TestToCsv_Scientific tests forcing exponent notation for floats
+func TestToCsv_Scientific(t *testing.T) {
+	// Create test array spanning many orders of magnitude
+	arr := &Array[float64]{
+		Data:    []float64{1e-10, 1e10, 1.5},
+		Shape:   []int{3},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-csv-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name     string
+		opts     CsvOptions
+		expected string
+	}{
+		{"default", CsvOptions{}, "1e-10,1e+10,1.5\n"},
+		{"scientific", CsvOptions{Scientific: true}, "1e-10,1e+10,1.5e+00\n"},
+		{"scientific_fixed", CsvOptions{Scientific: true, FloatFormat: FloatFixed, Decimals: 2}, "1e-10,1e+10,1.5e+00\n"},
+	}
+
+	for _, tt := range tests {
+		csvPath := filepath.Join(tempDir, tt.name+".csv")
+		if err := ToCsvWithOptions(arr, csvPath, tt.opts); err != nil {
+			t.Fatalf("Failed to export to Csv: %v", err)
+		}
+		got, err := os.ReadFile(csvPath)
+		if err != nil {
+			t.Fatalf("Failed to read Csv file: %v", err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("Unexpected %s output. Got %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+// TestToCsvGz tests exporting arrays to gzip-compressed Csv files
+func TestToCsvGz(t *testing.T) {
+	// Create test array - 2x3 matrix
+	arr := &Array[float64]{
+		Data:    []float64{1, 2.5, 3, 4, 5, 6.25},
+		Shape:   []int{2, 3},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-csv-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// readGzCsv decompresses and parses a .csv.gz file
+	readGzCsv := func(path string) [][]string {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", path, err)
+		}
+		defer f.Close()
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatalf("Failed to open gzip stream: %v", err)
+		}
+		records, err := csv.NewReader(gz).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to parse Csv: %v", err)
+		}
+		return records
+	}
+	expected := [][]string{{"1", "2.5", "3"}, {"4", "5", "6.25"}}
+
+	// Export a single array
+	gzPath := filepath.Join(tempDir, "matrix.csv.gz")
+	if err := ToCsvGz(arr, gzPath, CsvOptions{}); err != nil {
+		t.Fatalf("Failed to export to Csv: %v", err)
+	}
+	if records := readGzCsv(gzPath); !reflect.DeepEqual(records, expected) {
+		t.Errorf("Records mismatch. Got %v, want %v", records, expected)
+	}
+
+	// Export an NPZ file with compression
+	npz := NewNPZFile()
+	Add(npz, "matrix", arr)
+	npzPath := filepath.Join(tempDir, "test.npz")
+	if err := WriteNPZFile(npzPath, npz); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+	csvDir := filepath.Join(tempDir, "csv")
+	if err := NPZToCsvDirWithOptions(npzPath, csvDir, CsvOptions{Compress: true}); err != nil {
+		t.Fatalf("Failed to export NPZ to Csv: %v", err)
+	}
+	if records := readGzCsv(filepath.Join(csvDir, "matrix.csv.gz")); !reflect.DeepEqual(records, expected) {
+		t.Errorf("Records mismatch. Got %v, want %v", records, expected)
+	}
+}
+
+// TestCsvToNpyStream tests streaming a CSV file into a .npy file
+func TestCsvToNpyStream(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-csv-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	csvPath := filepath.Join(tempDir, "input.csv")
+	if err := os.WriteFile(csvPath, []byte("1,2,3\n4, 5 ,6\n-7,8,9\n10,11,12\n"), 0644); err != nil {
+		t.Fatalf("Failed to write CSV file: %v", err)
+	}
+
+	npyPath := filepath.Join(tempDir, "output.npy")
+	if err := CsvToNpyStream(csvPath, npyPath, Int32, 3); err != nil {
+		t.Fatalf("Failed to convert CSV file: %v", err)
+	}
+
+	arr, err := ReadFile[int32](npyPath)
+	if err != nil {
+		t.Fatalf("Failed to read converted file: %v", err)
+	}
+	expected := []int32{1, 2, 3, 4, 5, 6, -7, 8, 9, 10, 11, 12}
+	if !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+	if !reflect.DeepEqual(arr.Shape, []int{4, 3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", arr.Shape, []int{4, 3})
+	}
+
+	// The patched header keeps the data 16-byte aligned
+	raw, err := os.ReadFile(npyPath)
+	if err != nil {
+		t.Fatalf("Failed to read converted file: %v", err)
+	}
+	if dataStart := len(raw) - 4*len(expected); dataStart%16 != 0 {
+		t.Errorf("Expected aligned data offset, got %d", dataStart)
+	}
+
+	// Values are parsed for the requested dtype
+	if err := CsvToNpyStream(csvPath, npyPath, Float32, 3); err != nil {
+		t.Fatalf("Failed to convert CSV file: %v", err)
+	}
+	floats, err := ReadFile[float32](npyPath)
+	if err != nil {
+		t.Fatalf("Failed to read converted file: %v", err)
+	}
+	if floats.Data[6] != -7 {
+		t.Errorf("Data mismatch. Got %v, want -7", floats.Data[6])
+	}
+
+	// Out-of-range values, ragged rows and unsupported dtypes are errors
+	if err := CsvToNpyStream(csvPath, npyPath, Uint8, 3); err == nil {
+		t.Error("Expected error for negative uint8 value, got nil")
+	}
+	if err := CsvToNpyStream(csvPath, npyPath, Int32, 2); err == nil {
+		t.Error("Expected error for mismatched column count, got nil")
+	}
+	if err := CsvToNpyStream(csvPath, npyPath, Bool, 3); err == nil {
+		t.Error("Expected error for bool dtype, got nil")
+	}
+}
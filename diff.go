@@ -0,0 +1,60 @@
+package npy
+
+import (
+	"fmt"
+)
+
+// Diff returns human-readable descriptions of the differences between two
+// arrays: dtype and shape mismatches followed by up to maxReports differing
+// elements with their N-D coordinates. Elements are compared by logical
+// position, so arrays in different memory orders compare correctly. A
+// maxReports of zero or less reports every differing element.
+func Diff[T comparable](a, b *Array[T], maxReports int) []string {
+	var reports []string
+
+	if a.DType != b.DType {
+		reports = append(reports, fmt.Sprintf("dtype mismatch: %s vs %s", a.DType, b.DType))
+	}
+
+	// Element positions are only meaningful when the shapes agree
+	if !equalShapes(a.Shape, b.Shape) {
+		return append(reports, fmt.Sprintf("shape mismatch: %v vs %v", a.Shape, b.Shape))
+	}
+	if len(a.Data) != len(b.Data) {
+		return append(reports, fmt.Sprintf("data length mismatch: %d vs %d", len(a.Data), len(b.Data)))
+	}
+
+	// Walk the elements in C order
+	differing := 0
+	for i := range a.Data {
+		coord := unravelIndex(i, a.Shape, false)
+		valA := a.Data[ravelIndex(coord, a.Shape, a.Fortran)]
+		valB := b.Data[ravelIndex(coord, b.Shape, b.Fortran)]
+		if valA == valB {
+			continue
+		}
+
+		differing++
+		if maxReports <= 0 || differing <= maxReports {
+			reports = append(reports, fmt.Sprintf("element %v differs: %v vs %v", coord, valA, valB))
+		}
+	}
+	if maxReports > 0 && differing > maxReports {
+		reports = append(reports, fmt.Sprintf("... and %d more differing elements", differing-maxReports))
+	}
+
+	return reports
+}
+
+// equalShapes reports whether two shapes have the same dimensions
+func equalShapes(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package npy
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDiff tests reporting differences between two arrays
+func TestDiff(t *testing.T) {
+	// Create test arrays differing in one element
+	a := &Array[int32]{
+		Data:    []int32{1, 2, 3, 4, 5, 6},
+		Shape:   []int{2, 3},
+		DType:   Int32,
+		Fortran: false,
+	}
+	b := &Array[int32]{
+		Data:    []int32{1, 2, 3, 4, 0, 6},
+		Shape:   []int{2, 3},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	// Verify the single differing element is reported with its coordinate
+	reports := Diff(a, b, 10)
+	expected := []string{"element [1 1] differs: 5 vs 0"}
+	if !reflect.DeepEqual(reports, expected) {
+		t.Errorf("Reports mismatch. Got %v, want %v", reports, expected)
+	}
+
+	// Verify identical arrays produce no reports
+	if reports := Diff(a, a, 10); len(reports) != 0 {
+		t.Errorf("Expected no reports for identical arrays, got %v", reports)
+	}
+
+	// Verify arrays with the same logical content in different orders are equal
+	fortran := &Array[int32]{
+		Data:    []int32{1, 4, 2, 5, 3, 6},
+		Shape:   []int{2, 3},
+		DType:   Int32,
+		Fortran: true,
+	}
+	if reports := Diff(a, fortran, 10); len(reports) != 0 {
+		t.Errorf("Expected no reports for reordered arrays, got %v", reports)
+	}
+
+	// Verify the report limit and summary line
+	c := &Array[int32]{
+		Data:    []int32{0, 0, 0, 0, 0, 0},
+		Shape:   []int{2, 3},
+		DType:   Int32,
+		Fortran: false,
+	}
+	reports = Diff(a, c, 2)
+	if len(reports) != 3 || reports[2] != "... and 4 more differing elements" {
+		t.Errorf("Unexpected limited reports: %v", reports)
+	}
+
+	// Verify shape and dtype mismatches are reported
+	d := &Array[int32]{
+		Data:  []int32{1, 2, 3, 4, 5, 6},
+		Shape: []int{3, 2},
+		DType: Int64,
+	}
+	reports = Diff(a, d, 10)
+	expected = []string{"dtype mismatch: int32 vs int64", "shape mismatch: [2 3] vs [3 2]"}
+	if !reflect.DeepEqual(reports, expected) {
+		t.Errorf("Reports mismatch. Got %v, want %v", reports, expected)
+	}
+}
@@ -0,0 +1,31 @@
+package npy
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// SwapBytes reverses the byte order of every element in the array in place.
+// It is a no-op for single-byte element types.
+func (a *Array[T]) SwapBytes() {
+	var zero T
+	size := int(unsafe.Sizeof(zero))
+	if size <= 1 || len(a.Data) == 0 {
+		return
+	}
+
+	// View the data as raw bytes and reverse each element's bytes
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(&a.Data[0])), len(a.Data)*size)
+	for start := 0; start < len(raw); start += size {
+		elem := raw[start : start+size]
+		for i, j := 0, size-1; i < j; i, j = i+1, j-1 {
+			elem[i], elem[j] = elem[j], elem[i]
+		}
+	}
+}
+
+// isBigEndian reports whether order stores the most significant byte first.
+// It also recognizes binary.NativeEndian on big-endian hosts.
+func isBigEndian(order binary.ByteOrder) bool {
+	return order.Uint16([]byte{0, 1}) == 1
+}
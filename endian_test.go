@@ -0,0 +1,41 @@
+package npy
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSwapBytes tests swapping element byte order in place
+func TestSwapBytes(t *testing.T) {
+	// Create test array
+	arr := &Array[int32]{
+		Data:    []int32{0x01020304, -2},
+		Shape:   []int{2},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	// Verify a single swap reverses the bytes
+	arr.SwapBytes()
+	if arr.Data[0] != 0x04030201 {
+		t.Errorf("Swapped value mismatch. Got %#x, want %#x", arr.Data[0], 0x04030201)
+	}
+
+	// Verify swapping twice restores the original values
+	arr.SwapBytes()
+	expected := []int32{0x01020304, -2}
+	if !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch after double swap. Got %v, want %v", arr.Data, expected)
+	}
+
+	// Verify single-byte types are unchanged
+	bytesArr := &Array[uint8]{
+		Data:  []uint8{1, 2, 3},
+		Shape: []int{3},
+		DType: Uint8,
+	}
+	bytesArr.SwapBytes()
+	if !reflect.DeepEqual(bytesArr.Data, []uint8{1, 2, 3}) {
+		t.Errorf("Single-byte data changed. Got %v", bytesArr.Data)
+	}
+}
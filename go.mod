@@ -1,3 +1,5 @@
 module github.com/datumbrain/npy
 
 go 1.22.5
+
+require gonum.org/v1/gonum v0.15.1
@@ -0,0 +1,47 @@
+//go:build gonum
+
+package npy
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ToDense converts a 2D float64 array into a gonum matrix, honoring the
+// array's memory order. Build with the gonum tag to enable it.
+func ToDense(a *Array[float64]) (*mat.Dense, error) {
+	if len(a.Shape) != 2 {
+		return nil, fmt.Errorf("expected 2D array, got shape %v", a.Shape)
+	}
+	rows, cols := a.Shape[0], a.Shape[1]
+	if len(a.Data) != rows*cols {
+		return nil, fmt.Errorf("data length (%d) does not match shape dimensions (%d)", len(a.Data), rows*cols)
+	}
+	if rows == 0 || cols == 0 {
+		return nil, fmt.Errorf("cannot convert empty array with shape %v", a.Shape)
+	}
+
+	// mat.Dense is row-major, so C-order data can be copied directly
+	data := append([]float64(nil), a.Data...)
+	if a.Fortran {
+		data = toCOrder(data, a.Shape)
+	}
+
+	return mat.NewDense(rows, cols, data), nil
+}
+
+// FromDense converts a gonum matrix into a 2D C-order float64 array
+func FromDense(m *mat.Dense) *Array[float64] {
+	rows, cols := m.Dims()
+	data := make([]float64, 0, rows*cols)
+	for r := 0; r < rows; r++ {
+		data = append(data, m.RawRowView(r)...)
+	}
+
+	return &Array[float64]{
+		Data:  data,
+		Shape: []int{rows, cols},
+		DType: Float64,
+	}
+}
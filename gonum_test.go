@@ -0,0 +1,51 @@
+//go:build gonum
+
+package npy
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDenseRoundTrip tests converting a 2x3 matrix to gonum and back
+func TestDenseRoundTrip(t *testing.T) {
+	// Create test array - [[1, 2, 3], [4, 5, 6]] in Fortran order
+	arr := &Array[float64]{
+		Data:    []float64{1, 4, 2, 5, 3, 6},
+		Shape:   []int{2, 3},
+		DType:   Float64,
+		Fortran: true,
+	}
+
+	dense, err := ToDense(arr)
+	if err != nil {
+		t.Fatalf("Failed to convert to Dense: %v", err)
+	}
+
+	// Verify element access honors the logical layout
+	if got := dense.At(0, 2); got != 3 {
+		t.Errorf("Element at (0,2) mismatch. Got %v, want %v", got, 3.0)
+	}
+	if got := dense.At(1, 0); got != 4 {
+		t.Errorf("Element at (1,0) mismatch. Got %v, want %v", got, 4.0)
+	}
+
+	// Convert back and verify C-order data
+	back := FromDense(dense)
+	expected := []float64{1, 2, 3, 4, 5, 6}
+	if !reflect.DeepEqual(back.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", back.Data, expected)
+	}
+	if !reflect.DeepEqual(back.Shape, arr.Shape) {
+		t.Errorf("Shape mismatch. Got %v, want %v", back.Shape, arr.Shape)
+	}
+	if back.Fortran {
+		t.Errorf("Expected C-order result")
+	}
+
+	// Verify non-2D arrays are rejected
+	vec := &Array[float64]{Data: []float64{1, 2}, Shape: []int{2}, DType: Float64}
+	if _, err := ToDense(vec); err == nil {
+		t.Error("Expected error for 1D array, got nil")
+	}
+}
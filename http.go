@@ -0,0 +1,69 @@
+package npy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ReadURL reads a NumPy array from a URL using http.DefaultClient
+func ReadURL[T any](ctx context.Context, url string) (*Array[T], error) {
+	return ReadURLWithClient[T](ctx, http.DefaultClient, url)
+}
+
+// ReadURLWithClient reads a NumPy array from a URL using the given HTTP
+// client, streaming the response body through Read
+func ReadURLWithClient[T any](ctx context.Context, client *http.Client, url string) (*Array[T], error) {
+	body, err := fetchURL(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return Read[T](body)
+}
+
+// ReadNPZURL reads multiple NumPy arrays from a .npz file at a URL using
+// http.DefaultClient
+func ReadNPZURL(ctx context.Context, url string) (*NPZFile, error) {
+	return ReadNPZURLWithClient(ctx, http.DefaultClient, url)
+}
+
+// ReadNPZURLWithClient reads multiple NumPy arrays from a .npz file at a URL
+// using the given HTTP client. The archive is buffered in memory because zip
+// decoding requires random access.
+func ReadNPZURLWithClient(ctx context.Context, client *http.Client, url string) (*NPZFile, error) {
+	return ReadNPZURLWithOptions(ctx, client, url, NPZBufferOptions{})
+}
+
+// ReadNPZURLWithOptions reads multiple NumPy arrays from a .npz file at a URL
+// using the given HTTP client, buffering the archive as described by opts
+func ReadNPZURLWithOptions(ctx context.Context, client *http.Client, url string, opts NPZBufferOptions) (*NPZFile, error) {
+	body, err := fetchURL(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return ReadNPZStream(body, opts)
+}
+
+// fetchURL issues a GET request and returns the response body on success
+func fetchURL(ctx context.Context, client *http.Client, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to fetch %s: unexpected status %s", url, resp.Status)
+	}
+
+	return resp.Body, nil
+}
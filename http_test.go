@@ -0,0 +1,93 @@
+package npy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestReadURL tests reading arrays served over HTTP
+func TestReadURL(t *testing.T) {
+	// Create test array
+	arr := &Array[float32]{
+		Data:    []float32{1.5, 2.5, 3.5, 4.5},
+		Shape:   []int{2, 2},
+		DType:   Float32,
+		Fortran: false,
+	}
+
+	var npyBuf bytes.Buffer
+	if err := Write(&npyBuf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+
+	// Create an NPZ file to serve
+	tempDir, err := os.MkdirTemp("", "npy-http-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	npz := NewNPZFile()
+	Add(npz, "weights", arr)
+	npzPath := filepath.Join(tempDir, "test.npz")
+	if err := WriteNPZFile(npzPath, npz); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+	npzBytes, err := os.ReadFile(npzPath)
+	if err != nil {
+		t.Fatalf("Failed to read NPZ file: %v", err)
+	}
+
+	// Serve both files
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/array.npy":
+			w.Write(npyBuf.Bytes())
+		case "/archive.npz":
+			w.Write(npzBytes)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// Read the single array
+	readArr, err := ReadURLWithClient[float32](context.Background(), server.Client(), server.URL+"/array.npy")
+	if err != nil {
+		t.Fatalf("Failed to read array from URL: %v", err)
+	}
+	if !reflect.DeepEqual(readArr.Data, arr.Data) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, arr.Data)
+	}
+
+	// Read the archive
+	readNPZ, err := ReadNPZURLWithClient(context.Background(), server.Client(), server.URL+"/archive.npz")
+	if err != nil {
+		t.Fatalf("Failed to read NPZ from URL: %v", err)
+	}
+	weights, ok := Get[float32](readNPZ, "weights")
+	if !ok {
+		t.Fatalf("Failed to get weights from NPZ")
+	}
+	if !reflect.DeepEqual(weights.Data, arr.Data) {
+		t.Errorf("Data mismatch for weights. Got %v, want %v", weights.Data, arr.Data)
+	}
+
+	// Verify error statuses are reported
+	if _, err := ReadURL[float32](context.Background(), server.URL+"/missing.npy"); err == nil {
+		t.Error("Expected error for missing URL, got nil")
+	}
+
+	// Verify context cancellation is respected
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ReadURL[float32](ctx, server.URL+"/array.npy"); err == nil {
+		t.Error("Expected error for cancelled context, got nil")
+	}
+}
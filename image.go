@@ -0,0 +1,95 @@
+package npy
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// ToGray converts a 2D uint8 array of shape (height, width) to a grayscale image
+func ToGray(a *Array[uint8]) (*image.Gray, error) {
+	if len(a.Shape) != 2 {
+		return nil, fmt.Errorf("grayscale images require a 2D array, got shape %v", a.Shape)
+	}
+	data, err := cOrderPixels(a)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewGray(image.Rect(0, 0, a.Shape[1], a.Shape[0]))
+	copy(img.Pix, data)
+	return img, nil
+}
+
+// ToRGBA converts a uint8 array of shape (height, width, 3) or (height, width, 4)
+// to an RGBA image. Three-channel arrays are made fully opaque; four-channel
+// arrays are copied as is, so they should hold alpha-premultiplied values as
+// image.RGBA expects.
+func ToRGBA(a *Array[uint8]) (*image.RGBA, error) {
+	if len(a.Shape) != 3 || (a.Shape[2] != 3 && a.Shape[2] != 4) {
+		return nil, fmt.Errorf("RGBA images require an array of shape (height, width, 3 or 4), got %v", a.Shape)
+	}
+	data, err := cOrderPixels(a)
+	if err != nil {
+		return nil, err
+	}
+
+	height, width, channels := a.Shape[0], a.Shape[1], a.Shape[2]
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	if channels == 4 {
+		copy(img.Pix, data)
+		return img, nil
+	}
+
+	for i := 0; i < width*height; i++ {
+		copy(img.Pix[i*4:i*4+3], data[i*3:i*3+3])
+		img.Pix[i*4+3] = 0xff
+	}
+	return img, nil
+}
+
+// FromImage converts an image to a C-order uint8 array of shape
+// (height, width, 3) if the image is opaque, or (height, width, 4) otherwise.
+// Pixels are converted with color.RGBAModel, so alpha is premultiplied as in
+// ToRGBA.
+func FromImage(img image.Image) *Array[uint8] {
+	bounds := img.Bounds()
+	channels := 4
+	if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+		channels = 3
+	}
+
+	data := make([]uint8, 0, bounds.Dx()*bounds.Dy()*channels)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			data = append(data, c.R, c.G, c.B)
+			if channels == 4 {
+				data = append(data, c.A)
+			}
+		}
+	}
+
+	return &Array[uint8]{
+		Data:  data,
+		Shape: []int{bounds.Dy(), bounds.Dx(), channels},
+		DType: Uint8,
+	}
+}
+
+// cOrderPixels validates the data length of an image array and returns its
+// data in C order
+func cOrderPixels(a *Array[uint8]) ([]uint8, error) {
+	total := 1
+	for _, dim := range a.Shape {
+		total *= dim
+	}
+	if len(a.Data) != total {
+		return nil, fmt.Errorf("data length (%d) does not match shape dimensions (%d)", len(a.Data), total)
+	}
+
+	if a.Fortran {
+		return toCOrder(a.Data, a.Shape), nil
+	}
+	return a.Data, nil
+}
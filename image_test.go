@@ -0,0 +1,137 @@
+package npy
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+// TestToGray tests converting a 2D array to a grayscale image
+func TestToGray(t *testing.T) {
+	// Create a 2x3 array
+	arr := &Array[uint8]{
+		Data:    []uint8{0, 50, 100, 150, 200, 250},
+		Shape:   []int{2, 3},
+		DType:   Uint8,
+		Fortran: false,
+	}
+
+	img, err := ToGray(arr)
+	if err != nil {
+		t.Fatalf("Failed to convert to gray: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 3 || b.Dy() != 2 {
+		t.Fatalf("Bounds mismatch. Got %v, want 3x2", b)
+	}
+	if got := img.GrayAt(2, 0).Y; got != 100 {
+		t.Errorf("Pixel (2, 0) mismatch. Got %d, want 100", got)
+	}
+	if got := img.GrayAt(0, 1).Y; got != 150 {
+		t.Errorf("Pixel (0, 1) mismatch. Got %d, want 150", got)
+	}
+
+	// Fortran-order arrays give the same image
+	fortran := &Array[uint8]{
+		Data:    []uint8{0, 150, 50, 200, 100, 250},
+		Shape:   []int{2, 3},
+		DType:   Uint8,
+		Fortran: true,
+	}
+	fimg, err := ToGray(fortran)
+	if err != nil {
+		t.Fatalf("Failed to convert Fortran array to gray: %v", err)
+	}
+	if got := fimg.GrayAt(2, 0).Y; got != 100 {
+		t.Errorf("Fortran pixel (2, 0) mismatch. Got %d, want 100", got)
+	}
+
+	// Only 2D arrays are accepted
+	if _, err := ToGray(&Array[uint8]{Data: []uint8{1, 2}, Shape: []int{2}, DType: Uint8}); err == nil {
+		t.Error("Expected error for 1D array, got nil")
+	}
+}
+
+// TestToRGBA tests converting 3- and 4-channel arrays to RGBA images
+func TestToRGBA(t *testing.T) {
+	// Create a 1x2 RGB array
+	rgb := &Array[uint8]{
+		Data:  []uint8{255, 0, 0, 0, 128, 255},
+		Shape: []int{1, 2, 3},
+		DType: Uint8,
+	}
+	img, err := ToRGBA(rgb)
+	if err != nil {
+		t.Fatalf("Failed to convert RGB array: %v", err)
+	}
+	if got, want := img.RGBAAt(0, 0), (color.RGBA{255, 0, 0, 255}); got != want {
+		t.Errorf("Pixel (0, 0) mismatch. Got %v, want %v", got, want)
+	}
+	if got, want := img.RGBAAt(1, 0), (color.RGBA{0, 128, 255, 255}); got != want {
+		t.Errorf("Pixel (1, 0) mismatch. Got %v, want %v", got, want)
+	}
+
+	// Create a 2x1 RGBA array
+	rgba := &Array[uint8]{
+		Data:  []uint8{10, 20, 30, 40, 50, 60, 70, 80},
+		Shape: []int{2, 1, 4},
+		DType: Uint8,
+	}
+	img, err = ToRGBA(rgba)
+	if err != nil {
+		t.Fatalf("Failed to convert RGBA array: %v", err)
+	}
+	if got, want := img.RGBAAt(0, 1), (color.RGBA{50, 60, 70, 80}); got != want {
+		t.Errorf("Pixel (0, 1) mismatch. Got %v, want %v", got, want)
+	}
+
+	// Other channel counts are rejected
+	if _, err := ToRGBA(&Array[uint8]{Data: []uint8{1, 2}, Shape: []int{1, 1, 2}, DType: Uint8}); err == nil {
+		t.Error("Expected error for 2-channel array, got nil")
+	}
+}
+
+// TestFromImage tests converting images to arrays
+func TestFromImage(t *testing.T) {
+	// Create a 3x2 image with one translucent pixel
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			img.SetRGBA(x, y, color.RGBA{uint8(x * 10), uint8(y * 10), 200, 255})
+		}
+	}
+	img.SetRGBA(2, 1, color.RGBA{10, 20, 30, 128})
+
+	arr := FromImage(img)
+	if !reflect.DeepEqual(arr.Shape, []int{2, 3, 4}) {
+		t.Fatalf("Shape mismatch. Got %v, want %v", arr.Shape, []int{2, 3, 4})
+	}
+	if arr.DType != Uint8 {
+		t.Errorf("DType mismatch. Got %v, want %v", arr.DType, Uint8)
+	}
+	if got := arr.Data[4:8]; !reflect.DeepEqual(got, []uint8{10, 0, 200, 255}) {
+		t.Errorf("Pixel (1, 0) mismatch. Got %v", got)
+	}
+	if got := arr.Data[20:24]; !reflect.DeepEqual(got, []uint8{10, 20, 30, 128}) {
+		t.Errorf("Pixel (2, 1) mismatch. Got %v", got)
+	}
+
+	// Opaque images have three channels
+	img.SetRGBA(2, 1, color.RGBA{10, 20, 30, 255})
+	arr = FromImage(img)
+	if !reflect.DeepEqual(arr.Shape, []int{2, 3, 3}) {
+		t.Fatalf("Shape mismatch. Got %v, want %v", arr.Shape, []int{2, 3, 3})
+	}
+	if got := arr.Data[15:18]; !reflect.DeepEqual(got, []uint8{10, 20, 30}) {
+		t.Errorf("Pixel (2, 1) mismatch. Got %v", got)
+	}
+
+	// Converting back gives the original image
+	back, err := ToRGBA(arr)
+	if err != nil {
+		t.Fatalf("Failed to convert back to RGBA: %v", err)
+	}
+	if !reflect.DeepEqual(back.Pix, img.Pix) {
+		t.Errorf("Round trip mismatch. Got %v, want %v", back.Pix, img.Pix)
+	}
+}
@@ -0,0 +1,136 @@
+package npy
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// unravelIndex converts a flat index into an N-D coordinate for the given
+// shape and memory order
+func unravelIndex(flat int, shape []int, fortran bool) []int {
+	coord := make([]int, len(shape))
+	if fortran {
+		// First dimension varies fastest
+		for d := 0; d < len(shape); d++ {
+			coord[d] = flat % shape[d]
+			flat /= shape[d]
+		}
+	} else {
+		// Last dimension varies fastest
+		for d := len(shape) - 1; d >= 0; d-- {
+			coord[d] = flat % shape[d]
+			flat /= shape[d]
+		}
+	}
+	return coord
+}
+
+// ravelIndex converts an N-D coordinate into a flat index for the given shape
+// and memory order
+func ravelIndex(coord []int, shape []int, fortran bool) int {
+	flat := 0
+	if fortran {
+		for d := len(shape) - 1; d >= 0; d-- {
+			flat = flat*shape[d] + coord[d]
+		}
+	} else {
+		for d := 0; d < len(shape); d++ {
+			flat = flat*shape[d] + coord[d]
+		}
+	}
+	return flat
+}
+
+// At returns the element at the given N-D coordinate, taking the array's
+// memory order into account
+func (a *Array[T]) At(coord ...int) (T, error) {
+	var zero T
+	if err := a.checkCoord(coord); err != nil {
+		return zero, err
+	}
+	return a.Data[ravelIndex(coord, a.Shape, a.Fortran)], nil
+}
+
+// Unravel converts a flat index into the array's Data into an N-D coordinate,
+// like np.unravel_index, honoring the array's memory order. It panics if flat
+// is out of range.
+func (a *Array[T]) Unravel(flat int) []int {
+	size := 1
+	for _, dim := range a.Shape {
+		size *= dim
+	}
+	if flat < 0 || flat >= size {
+		panic(fmt.Sprintf("npy: flat index %d out of range for array of size %d", flat, size))
+	}
+	return unravelIndex(flat, a.Shape, a.Fortran)
+}
+
+// Ravel converts an N-D coordinate into a flat index into the array's Data,
+// honoring the array's memory order. It panics if coord does not address an
+// element of the array.
+func (a *Array[T]) Ravel(coord []int) int {
+	if err := a.checkCoord(coord); err != nil {
+		panic("npy: " + err.Error())
+	}
+	return ravelIndex(coord, a.Shape, a.Fortran)
+}
+
+// checkCoord reports whether coord has one in-range entry per dimension
+func (a *Array[T]) checkCoord(coord []int) error {
+	if len(coord) != len(a.Shape) {
+		return fmt.Errorf("expected %d coordinates for array of rank %d, got %d", len(a.Shape), len(a.Shape), len(coord))
+	}
+	for d, c := range coord {
+		if c < 0 || c >= a.Shape[d] {
+			return fmt.Errorf("coordinate %d out of range for axis %d with size %d", c, d, a.Shape[d])
+		}
+	}
+	return nil
+}
+
+// Strides returns the number of bytes to step in each dimension when
+// traversing the array, based on its shape, memory order and dtype item size
+func (a *Array[T]) Strides() []int {
+	itemSize := a.DType.ItemSize()
+	if itemSize == 0 {
+		var zero T
+		itemSize = int(unsafe.Sizeof(zero))
+	}
+
+	strides := make([]int, len(a.Shape))
+	stride := itemSize
+	if a.Fortran {
+		for d := 0; d < len(a.Shape); d++ {
+			strides[d] = stride
+			stride *= a.Shape[d]
+		}
+	} else {
+		for d := len(a.Shape) - 1; d >= 0; d-- {
+			strides[d] = stride
+			stride *= a.Shape[d]
+		}
+	}
+	return strides
+}
+
+// Nbytes returns the size in bytes of the array's elements as stored in a
+// .npy file, like ndarray.nbytes
+func (a *Array[T]) Nbytes() int {
+	itemSize := a.DType.ItemSize()
+	if itemSize == 0 {
+		var zero T
+		itemSize = int(unsafe.Sizeof(zero))
+	}
+	return len(a.Data) * itemSize
+}
+
+// MemoryFootprint estimates the Go heap usage of the array in bytes: the
+// struct itself plus the backing arrays of Data and Shape, counted by capacity.
+// Arrays sharing backing storage are each charged for it in full.
+func (a *Array[T]) MemoryFootprint() int {
+	var zero T
+	return int(unsafe.Sizeof(*a)) +
+		cap(a.Data)*int(unsafe.Sizeof(zero)) +
+		cap(a.Shape)*int(unsafe.Sizeof(int(0))) +
+		len(a.descr)
+}
@@ -0,0 +1,171 @@
+package npy
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+// TestStrides tests byte strides for a 2x3x4 float32 array in both orders
+func TestStrides(t *testing.T) {
+	// Create test array
+	arr := &Array[float32]{
+		Data:    make([]float32, 24),
+		Shape:   []int{2, 3, 4},
+		DType:   Float32,
+		Fortran: false,
+	}
+
+	// C order: last dimension is contiguous
+	expected := []int{48, 16, 4}
+	if strides := arr.Strides(); !reflect.DeepEqual(strides, expected) {
+		t.Errorf("C-order strides mismatch. Got %v, want %v", strides, expected)
+	}
+
+	// Fortran order: first dimension is contiguous
+	arr.Fortran = true
+	expected = []int{4, 8, 24}
+	if strides := arr.Strides(); !reflect.DeepEqual(strides, expected) {
+		t.Errorf("Fortran-order strides mismatch. Got %v, want %v", strides, expected)
+	}
+}
+
+// TestRavelIndex tests flat index computation for a 2x3x4 shape in both orders
+func TestRavelIndex(t *testing.T) {
+	shape := []int{2, 3, 4}
+
+	// Flat indices must match the byte strides divided by the item size
+	for _, fortran := range []bool{false, true} {
+		arr := &Array[uint8]{Data: make([]uint8, 24), Shape: shape, DType: Uint8, Fortran: fortran}
+		strides := arr.Strides()
+		seen := make(map[int]bool)
+		for i := 0; i < 2; i++ {
+			for j := 0; j < 3; j++ {
+				for k := 0; k < 4; k++ {
+					coord := []int{i, j, k}
+					flat := ravelIndex(coord, shape, fortran)
+					expected := i*strides[0] + j*strides[1] + k*strides[2]
+					if flat != expected {
+						t.Errorf("ravelIndex(%v, fortran=%v) = %d, want %d", coord, fortran, flat, expected)
+					}
+					if back := unravelIndex(flat, shape, fortran); !reflect.DeepEqual(back, coord) {
+						t.Errorf("unravelIndex(%d, fortran=%v) = %v, want %v", flat, fortran, back, coord)
+					}
+					seen[flat] = true
+				}
+			}
+		}
+		if len(seen) != 24 {
+			t.Errorf("Expected 24 distinct indices with fortran=%v, got %d", fortran, len(seen))
+		}
+	}
+
+	// Spot-check both layouts
+	if got := ravelIndex([]int{1, 2, 3}, shape, false); got != 23 {
+		t.Errorf("C-order index mismatch. Got %d, want 23", got)
+	}
+	if got := ravelIndex([]int{1, 0, 1}, shape, true); got != 7 {
+		t.Errorf("Fortran-order index mismatch. Got %d, want 7", got)
+	}
+}
+
+// TestAt tests element access by coordinate in both orders
+func TestAt(t *testing.T) {
+	// The same 2x3 matrix in C and Fortran order
+	c := &Array[int32]{Data: []int32{1, 2, 3, 4, 5, 6}, Shape: []int{2, 3}, DType: Int32}
+	f := &Array[int32]{Data: []int32{1, 4, 2, 5, 3, 6}, Shape: []int{2, 3}, DType: Int32, Fortran: true}
+
+	for _, arr := range []*Array[int32]{c, f} {
+		got, err := arr.At(1, 2)
+		if err != nil {
+			t.Fatalf("Failed to get element: %v", err)
+		}
+		if got != 6 {
+			t.Errorf("At(1, 2) mismatch with fortran=%v. Got %d, want 6", arr.Fortran, got)
+		}
+		got, _ = arr.At(0, 1)
+		if got != 2 {
+			t.Errorf("At(0, 1) mismatch with fortran=%v. Got %d, want 2", arr.Fortran, got)
+		}
+	}
+
+	// Invalid coordinates are rejected
+	if _, err := c.At(2, 0); err == nil {
+		t.Error("Expected error for out-of-range coordinate, got nil")
+	}
+	if _, err := c.At(0); err == nil {
+		t.Error("Expected error for wrong number of coordinates, got nil")
+	}
+}
+
+// TestUnravelRavel tests converting between flat indices and coordinates in both orders
+func TestUnravelRavel(t *testing.T) {
+	shape := []int{3, 4, 5}
+
+	for _, fortran := range []bool{false, true} {
+		arr := &Array[int32]{Data: make([]int32, 60), Shape: shape, DType: Int32, Fortran: fortran}
+		for flat := 0; flat < 60; flat++ {
+			coord := arr.Unravel(flat)
+			if back := arr.Ravel(coord); back != flat {
+				t.Errorf("Ravel(Unravel(%d)) = %d with fortran=%v", flat, back, fortran)
+			}
+		}
+	}
+
+	// Spot-check both layouts against np.unravel_index
+	c := &Array[int32]{Data: make([]int32, 60), Shape: shape, DType: Int32}
+	if got := c.Unravel(27); !reflect.DeepEqual(got, []int{1, 1, 2}) {
+		t.Errorf("C-order coordinate mismatch. Got %v, want %v", got, []int{1, 1, 2})
+	}
+	f := &Array[int32]{Data: make([]int32, 60), Shape: shape, DType: Int32, Fortran: true}
+	if got := f.Unravel(27); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("Fortran-order coordinate mismatch. Got %v, want %v", got, []int{0, 1, 2})
+	}
+	if got := f.Ravel([]int{2, 3, 4}); got != 59 {
+		t.Errorf("Fortran-order index mismatch. Got %d, want 59", got)
+	}
+
+	// Out-of-range arguments panic
+	for name, call := range map[string]func(){
+		"negative flat":    func() { c.Unravel(-1) },
+		"flat too large":   func() { c.Unravel(60) },
+		"short coordinate": func() { c.Ravel([]int{1, 2}) },
+		"coordinate range": func() { c.Ravel([]int{0, 4, 0}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for %s", name)
+				}
+			}()
+			call()
+		}()
+	}
+}
+
+// TestMemoryFootprint tests the payload size and the Go heap estimate
+func TestMemoryFootprint(t *testing.T) {
+	arr := &Array[float64]{Data: make([]float64, 10), Shape: []int{2, 5}, DType: Float64}
+
+	if got := arr.Nbytes(); got != 80 {
+		t.Errorf("Nbytes mismatch. Got %d, want 80", got)
+	}
+
+	// The struct and the shape's backing array are the overhead
+	overhead := int(unsafe.Sizeof(*arr)) + 2*int(unsafe.Sizeof(int(0)))
+	if got := arr.MemoryFootprint(); got != arr.Nbytes()+overhead {
+		t.Errorf("MemoryFootprint mismatch. Got %d, want %d", got, arr.Nbytes()+overhead)
+	}
+
+	// Spare capacity in Data is counted
+	spare := &Array[float64]{Data: make([]float64, 10, 20), Shape: []int{2, 5}, DType: Float64}
+	if got := spare.MemoryFootprint(); got != arr.MemoryFootprint()+80 {
+		t.Errorf("MemoryFootprint mismatch. Got %d, want %d", got, arr.MemoryFootprint()+80)
+	}
+
+	// Nbytes follows the dtype's item size
+	bools := &Array[bool]{Data: make([]bool, 3), Shape: []int{3}, DType: Bool}
+	if got := bools.Nbytes(); got != 3 {
+		t.Errorf("Nbytes mismatch. Got %d, want 3", got)
+	}
+}
@@ -0,0 +1,84 @@
+package npy
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteInts writes a slice of Go ints to an io.Writer as an int64 NumPy array
+// with the given shape
+func WriteInts(w io.Writer, data []int, shape []int) error {
+	// int is at most 64 bits wide, so the conversion is always lossless
+	converted := make([]int64, len(data))
+	for i, val := range data {
+		converted[i] = int64(val)
+	}
+
+	return Write(w, &Array[int64]{
+		Data:  converted,
+		Shape: shape,
+		DType: Int64,
+	})
+}
+
+// ReadInts reads an integer NumPy array from an io.Reader as a slice of Go
+// ints, returning the data in C order and the shape. Fortran-order arrays are
+// rearranged so the result is always row-major. It errors if any value does
+// not fit in an int on this platform.
+func ReadInts(r io.Reader) ([]int, []int, error) {
+	// Read and parse header
+	hdr, _, err := readHeader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Read data based on dtype
+	var data []int
+	switch hdr.DType {
+	case Int8:
+		data, err = readInts[int8](r, hdr)
+	case Int16:
+		data, err = readInts[int16](r, hdr)
+	case Int32:
+		data, err = readInts[int32](r, hdr)
+	case Int64:
+		data, err = readInts[int64](r, hdr)
+	case Uint8:
+		data, err = readInts[uint8](r, hdr)
+	case Uint16:
+		data, err = readInts[uint16](r, hdr)
+	case Uint32:
+		data, err = readInts[uint32](r, hdr)
+	case Uint64:
+		data, err = readInts[uint64](r, hdr)
+	default:
+		return nil, nil, fmt.Errorf("expected integer dtype, got %s", hdr.DType)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Return row-major data regardless of the stored order
+	if hdr.Fortran {
+		data = toCOrder(data, hdr.Shape)
+	}
+
+	return data, hdr.Shape, nil
+}
+
+// readInts reads integer data of type T and converts it to ints, checking for overflow
+func readInts[T Integer](r io.Reader, hdr *header) ([]int, error) {
+	raw, err := readData[T](r, hdr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data: %w", err)
+	}
+
+	data := make([]int, len(raw))
+	for i, val := range raw {
+		data[i] = int(val)
+		if T(data[i]) != val || (data[i] < 0) != (val < 0) {
+			return nil, fmt.Errorf("value %v at index %d overflows int", val, i)
+		}
+	}
+	return data, nil
+}
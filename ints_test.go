@@ -0,0 +1,102 @@
+package npy
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestWriteReadInts tests writing a []int and reading it back
+func TestWriteReadInts(t *testing.T) {
+	// Create test data
+	data := []int{-3, 0, 7, 1 << 40, -(1 << 40), 42}
+	shape := []int{2, 3}
+
+	// Write ints to buffer
+	var buf bytes.Buffer
+	if err := WriteInts(&buf, data, shape); err != nil {
+		t.Fatalf("Failed to write ints: %v", err)
+	}
+
+	// Read back as int64
+	readArr, err := Read[int64](bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if readArr.DType != Int64 {
+		t.Errorf("DType mismatch. Got %v, want %v", readArr.DType, Int64)
+	}
+	expected := []int64{-3, 0, 7, 1 << 40, -(1 << 40), 42}
+	if !reflect.DeepEqual(readArr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, expected)
+	}
+
+	// Read back as ints
+	readInts, readShape, err := ReadInts(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to read ints: %v", err)
+	}
+	if !reflect.DeepEqual(readInts, data) {
+		t.Errorf("Data mismatch. Got %v, want %v", readInts, data)
+	}
+	if !reflect.DeepEqual(readShape, shape) {
+		t.Errorf("Shape mismatch. Got %v, want %v", readShape, shape)
+	}
+}
+
+// TestReadIntsOverflow tests that values too large for int are rejected
+func TestReadIntsOverflow(t *testing.T) {
+	// Create a uint64 array holding a value larger than any int
+	arr := &Array[uint64]{
+		Data:  []uint64{1, math.MaxUint64},
+		Shape: []int{2},
+		DType: Uint64,
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+
+	if _, _, err := ReadInts(bytes.NewReader(buf.Bytes())); err == nil {
+		t.Error("Expected overflow error, got nil")
+	}
+
+	// Verify non-integer dtypes are rejected
+	buf.Reset()
+	floats := &Array[float32]{Data: []float32{1}, Shape: []int{1}, DType: Float32}
+	if err := Write(&buf, floats); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if _, _, err := ReadInts(bytes.NewReader(buf.Bytes())); err == nil {
+		t.Error("Expected error for float dtype, got nil")
+	}
+}
+
+// TestReadIntsFortran tests that Fortran-order int arrays are returned in C order
+func TestReadIntsFortran(t *testing.T) {
+	// [[1, 2, 3], [4, 5, 6]] stored column-major
+	arr := &Array[int64]{
+		Data:    []int64{1, 4, 2, 5, 3, 6},
+		Shape:   []int{2, 3},
+		DType:   Int64,
+		Fortran: true,
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+
+	data, shape, err := ReadInts(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to read ints: %v", err)
+	}
+	if expected := []int{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", data, expected)
+	}
+	if !reflect.DeepEqual(shape, []int{2, 3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", shape, []int{2, 3})
+	}
+}
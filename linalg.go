@@ -0,0 +1,164 @@
+package npy
+
+import (
+	"fmt"
+)
+
+// MatMul multiplies two 2D arrays, honoring each operand's memory order, and
+// returns the product as a C-order array
+func MatMul[T Numeric](a, b *Array[T]) (*Array[T], error) {
+	// Validate shapes
+	if len(a.Shape) != 2 || len(b.Shape) != 2 {
+		return nil, fmt.Errorf("matrix multiplication requires 2D arrays, got shapes %v and %v", a.Shape, b.Shape)
+	}
+	if a.Shape[1] != b.Shape[0] {
+		return nil, fmt.Errorf("incompatible shapes for matrix multiplication: %v and %v", a.Shape, b.Shape)
+	}
+
+	rows, inner, cols := a.Shape[0], a.Shape[1], b.Shape[1]
+	data := make([]T, rows*cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			var sum T
+			for k := 0; k < inner; k++ {
+				sum += a.Data[ravelIndex([]int{r, k}, a.Shape, a.Fortran)] *
+					b.Data[ravelIndex([]int{k, c}, b.Shape, b.Fortran)]
+			}
+			data[r*cols+c] = sum
+		}
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: []int{rows, cols},
+		DType: a.DType,
+	}, nil
+}
+
+// Dot computes the inner product of two equal-length 1D arrays
+func Dot[T Numeric](a, b *Array[T]) (T, error) {
+	var sum T
+
+	// Validate shapes
+	if len(a.Shape) != 1 || len(b.Shape) != 1 {
+		return sum, fmt.Errorf("dot product requires 1D arrays, got shapes %v and %v", a.Shape, b.Shape)
+	}
+	if a.Shape[0] != b.Shape[0] {
+		return sum, fmt.Errorf("length mismatch for dot product: %d and %d", a.Shape[0], b.Shape[0])
+	}
+
+	for i := range a.Data {
+		sum += a.Data[i] * b.Data[i]
+	}
+
+	return sum, nil
+}
+
+// Diagonal returns the main diagonal of a 2D array as a 1D array, honoring
+// the array's memory order. Non-square arrays yield min(rows, cols) values.
+func (a *Array[T]) Diagonal() (*Array[T], error) {
+	if len(a.Shape) != 2 {
+		return nil, fmt.Errorf("diagonal requires a 2D array, got shape %v", a.Shape)
+	}
+
+	n := min(a.Shape[0], a.Shape[1])
+	data := make([]T, n)
+	for i := range data {
+		data[i] = a.Data[ravelIndex([]int{i, i}, a.Shape, a.Fortran)]
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: []int{n},
+		DType: a.DType,
+	}, nil
+}
+
+// Eye returns an n×n identity matrix as a C-order array. It panics if n is
+// negative
+func Eye[T Numeric](n int) *Array[T] {
+	if n < 0 {
+		panic(fmt.Sprintf("npy: negative size %d for identity matrix", n))
+	}
+
+	data := make([]T, n*n)
+	for i := 0; i < n; i++ {
+		data[i*n+i] = 1
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: []int{n, n},
+		DType: dtypeOf[T](),
+	}
+}
+
+// Broadcast expands both arrays to a common shape following NumPy broadcasting
+// rules: shapes are aligned from the trailing dimension and dimensions of size
+// 1 are stretched to match. Both results are C-order arrays.
+func Broadcast[T Numeric](a, b *Array[T]) (*Array[T], *Array[T], error) {
+	// Determine the broadcast shape
+	ndim := len(a.Shape)
+	if len(b.Shape) > ndim {
+		ndim = len(b.Shape)
+	}
+	shape := make([]int, ndim)
+	for i := 1; i <= ndim; i++ {
+		dimA, dimB := 1, 1
+		if i <= len(a.Shape) {
+			dimA = a.Shape[len(a.Shape)-i]
+		}
+		if i <= len(b.Shape) {
+			dimB = b.Shape[len(b.Shape)-i]
+		}
+
+		switch {
+		case dimA == dimB || dimB == 1:
+			shape[ndim-i] = dimA
+		case dimA == 1:
+			shape[ndim-i] = dimB
+		default:
+			return nil, nil, fmt.Errorf("shapes %v and %v cannot be broadcast together", a.Shape, b.Shape)
+		}
+	}
+
+	return broadcastTo(a, shape), broadcastTo(b, shape), nil
+}
+
+// broadcastTo expands an array to a compatible target shape as a C-order array
+func broadcastTo[T any](a *Array[T], shape []int) *Array[T] {
+	total := 1
+	for _, dim := range shape {
+		total *= dim
+	}
+	data := make([]T, total)
+
+	// Walk the target coordinates in C order, mapping each to the source
+	offset := len(shape) - len(a.Shape)
+	coord := make([]int, len(shape))
+	srcCoord := make([]int, len(a.Shape))
+	for i := range data {
+		for d := range srcCoord {
+			if a.Shape[d] == 1 {
+				srcCoord[d] = 0
+			} else {
+				srcCoord[d] = coord[d+offset]
+			}
+		}
+		data[i] = a.Data[ravelIndex(srcCoord, a.Shape, a.Fortran)]
+
+		for d := len(coord) - 1; d >= 0; d-- {
+			coord[d]++
+			if coord[d] < shape[d] {
+				break
+			}
+			coord[d] = 0
+		}
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: append([]int(nil), shape...),
+		DType: a.DType,
+	}
+}
@@ -0,0 +1,208 @@
+package npy
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMatMul tests multiplying a 2x3 matrix by a 3x2 matrix
+func TestMatMul(t *testing.T) {
+	// Create test arrays - [[1, 2, 3], [4, 5, 6]] in C order
+	a := &Array[int32]{
+		Data:    []int32{1, 2, 3, 4, 5, 6},
+		Shape:   []int{2, 3},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	// [[7, 8], [9, 10], [11, 12]] in Fortran order
+	b := &Array[int32]{
+		Data:    []int32{7, 9, 11, 8, 10, 12},
+		Shape:   []int{3, 2},
+		DType:   Int32,
+		Fortran: true,
+	}
+
+	result, err := MatMul(a, b)
+	if err != nil {
+		t.Fatalf("Failed to multiply matrices: %v", err)
+	}
+
+	// Verify data
+	expected := []int32{58, 64, 139, 154}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", result.Data, expected)
+	}
+
+	// Verify shape and order
+	if !reflect.DeepEqual(result.Shape, []int{2, 2}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", result.Shape, []int{2, 2})
+	}
+	if result.Fortran {
+		t.Errorf("Expected C-order result")
+	}
+
+	// Verify incompatible shapes are rejected
+	if _, err := MatMul(a, a); err == nil {
+		t.Error("Expected error for incompatible shapes, got nil")
+	}
+}
+
+// TestDot tests the inner product of two length-4 vectors
+func TestDot(t *testing.T) {
+	// Create test arrays
+	a := &Array[float64]{
+		Data:    []float64{1.0, 2.0, 3.0, 4.0},
+		Shape:   []int{4},
+		DType:   Float64,
+		Fortran: false,
+	}
+	b := &Array[float64]{
+		Data:    []float64{0.5, -1.0, 2.0, 0.25},
+		Shape:   []int{4},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	result, err := Dot(a, b)
+	if err != nil {
+		t.Fatalf("Failed to compute dot product: %v", err)
+	}
+
+	// 0.5 - 2 + 6 + 1 = 5.5
+	if result != 5.5 {
+		t.Errorf("Dot product mismatch. Got %v, want %v", result, 5.5)
+	}
+
+	// Verify length mismatch is rejected
+	short := &Array[float64]{Data: []float64{1, 2}, Shape: []int{2}, DType: Float64}
+	if _, err := Dot(a, short); err == nil {
+		t.Error("Expected error for length mismatch, got nil")
+	}
+
+	// Verify non-1D arrays are rejected
+	matrix := &Array[float64]{Data: []float64{1, 2, 3, 4}, Shape: []int{2, 2}, DType: Float64}
+	if _, err := Dot(a, matrix); err == nil {
+		t.Error("Expected error for 2D operand, got nil")
+	}
+}
+
+// TestEye tests constructing a 3x3 identity matrix
+func TestEye(t *testing.T) {
+	eye := Eye[float32](3)
+
+	// Verify shape and dtype
+	if !reflect.DeepEqual(eye.Shape, []int{3, 3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", eye.Shape, []int{3, 3})
+	}
+	if eye.DType != Float32 {
+		t.Errorf("DType mismatch. Got %v, want %v", eye.DType, Float32)
+	}
+
+	// Verify diagonal and off-diagonal elements
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			want := float32(0)
+			if r == c {
+				want = 1
+			}
+			if got := eye.Data[r*3+c]; got != want {
+				t.Errorf("Element at (%d,%d) mismatch. Got %v, want %v", r, c, got, want)
+			}
+		}
+	}
+
+	// A zero size gives an empty matrix, a negative one panics
+	if empty := Eye[int32](0); len(empty.Data) != 0 || !reflect.DeepEqual(empty.Shape, []int{0, 0}) {
+		t.Errorf("Empty identity mismatch. Got shape %v, data %v", empty.Shape, empty.Data)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for negative size")
+			}
+		}()
+		Eye[float64](-1)
+	}()
+}
+
+// TestBroadcast tests broadcasting a column and a row to a common shape
+func TestBroadcast(t *testing.T) {
+	// Create test arrays - a (3,1) column and a (1,4) row
+	col := &Array[int32]{
+		Data:    []int32{1, 2, 3},
+		Shape:   []int{3, 1},
+		DType:   Int32,
+		Fortran: false,
+	}
+	row := &Array[int32]{
+		Data:    []int32{10, 20, 30, 40},
+		Shape:   []int{1, 4},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	bCol, bRow, err := Broadcast(col, row)
+	if err != nil {
+		t.Fatalf("Failed to broadcast arrays: %v", err)
+	}
+
+	// Verify shapes
+	expectedShape := []int{3, 4}
+	if !reflect.DeepEqual(bCol.Shape, expectedShape) || !reflect.DeepEqual(bRow.Shape, expectedShape) {
+		t.Errorf("Shape mismatch. Got %v and %v, want %v", bCol.Shape, bRow.Shape, expectedShape)
+	}
+
+	// Verify data
+	expectedCol := []int32{1, 1, 1, 1, 2, 2, 2, 2, 3, 3, 3, 3}
+	if !reflect.DeepEqual(bCol.Data, expectedCol) {
+		t.Errorf("Column data mismatch. Got %v, want %v", bCol.Data, expectedCol)
+	}
+	expectedRow := []int32{10, 20, 30, 40, 10, 20, 30, 40, 10, 20, 30, 40}
+	if !reflect.DeepEqual(bRow.Data, expectedRow) {
+		t.Errorf("Row data mismatch. Got %v, want %v", bRow.Data, expectedRow)
+	}
+
+	// Verify incompatible shapes are rejected
+	bad := &Array[int32]{Data: []int32{1, 2}, Shape: []int{2}, DType: Int32}
+	if _, _, err := Broadcast(row, bad); err == nil {
+		t.Error("Expected error for incompatible shapes, got nil")
+	}
+}
+
+// TestDiagonal tests extracting the main diagonal of 2D arrays
+func TestDiagonal(t *testing.T) {
+	// Create a 3x3 matrix
+	arr := &Array[float64]{
+		Data:    []float64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		Shape:   []int{3, 3},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	diag, err := arr.Diagonal()
+	if err != nil {
+		t.Fatalf("Failed to extract diagonal: %v", err)
+	}
+	if !reflect.DeepEqual(diag.Shape, []int{3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", diag.Shape, []int{3})
+	}
+	if expected := []float64{1, 5, 9}; !reflect.DeepEqual(diag.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", diag.Data, expected)
+	}
+
+	// Fortran-order, non-square matrix [[1, 2, 3], [4, 5, 6]]
+	fortran := &Array[float64]{Data: []float64{1, 4, 2, 5, 3, 6}, Shape: []int{2, 3}, DType: Float64, Fortran: true}
+	diag, err = fortran.Diagonal()
+	if err != nil {
+		t.Fatalf("Failed to extract diagonal: %v", err)
+	}
+	if expected := []float64{1, 5}; !reflect.DeepEqual(diag.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", diag.Data, expected)
+	}
+
+	// Only 2D arrays have a diagonal
+	if _, err := (&Array[float64]{Data: []float64{1}, Shape: []int{1}, DType: Float64}).Diagonal(); err == nil {
+		t.Error("Expected error for 1D array, got nil")
+	}
+}
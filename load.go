@@ -0,0 +1,87 @@
+package npy
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Load reads a .npy, .npz or .csv file into an NPZ file, choosing the format
+// by extension. A .npy file becomes a single entry keyed by its base name
+// without the extension, as does a .csv file, which is read as float64.
+func Load(path string) (*NPZFile, error) {
+	ext := filepath.Ext(path)
+	name := strings.TrimSuffix(filepath.Base(path), ext)
+
+	switch ext {
+	case ".npy":
+		array, err := readAnyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		npz := NewNPZFile()
+		npz.arrays[name] = array
+		return npz, nil
+	case ".npz":
+		return ReadNPZFile(path)
+	case ".csv":
+		arr, err := FromCsv(path)
+		if err != nil {
+			return nil, err
+		}
+		npz := NewNPZFile()
+		Add(npz, name, arr)
+		return npz, nil
+	default:
+		return nil, fmt.Errorf("unsupported file extension %q in %s", ext, path)
+	}
+}
+
+// DetectFormat reports whether r holds a .npy or .npz file by checking its
+// leading signature, returning "npy" or "npz". Only the first few bytes are
+// read.
+func DetectFormat(r io.ReaderAt) (string, error) {
+	magic := make([]byte, 6)
+	n, err := r.ReadAt(magic, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read signature: %w", err)
+	}
+	return detectFormat(magic[:n])
+}
+
+// OpenAuto reads a .npy or .npz stream, detecting the format from its leading
+// bytes. A .npy stream is returned as an *Array[T] for its element type and a
+// .npz stream, which is buffered in memory, as an *NPZFile.
+func OpenAuto(r io.Reader) (interface{}, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(6)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read signature: %w", err)
+	}
+
+	format, err := detectFormat(magic)
+	if err != nil {
+		return nil, err
+	}
+	if format == "npz" {
+		return ReadNPZStream(br, NPZBufferOptions{})
+	}
+	return readAny(br, ReadOptions{})
+}
+
+// detectFormat identifies the format from the leading bytes of a file
+func detectFormat(magic []byte) (string, error) {
+	switch {
+	case bytes.HasPrefix(magic, []byte("\x93NUMPY")):
+		return "npy", nil
+	case bytes.HasPrefix(magic, []byte("PK\x03\x04")), bytes.HasPrefix(magic, []byte("PK\x05\x06")):
+		// An archive with no entries starts with the end of central directory record
+		return "npz", nil
+	default:
+		return "", fmt.Errorf("unrecognized file signature %q", magic)
+	}
+}
@@ -0,0 +1,201 @@
+package npy
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestLoad tests loading .npy, .npz and .csv files through a single entry point
+func TestLoad(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	arr := &Array[int32]{Data: []int32{1, 2, 3, 4}, Shape: []int{2, 2}, DType: Int32}
+
+	t.Run("npy", func(t *testing.T) {
+		path := filepath.Join(tempDir, "weights.npy")
+		if err := WriteFile(path, arr); err != nil {
+			t.Fatalf("Failed to write .npy file: %v", err)
+		}
+		npz, err := Load(path)
+		if err != nil {
+			t.Fatalf("Failed to load .npy file: %v", err)
+		}
+		got, ok := Get[int32](npz, "weights")
+		if !ok || !reflect.DeepEqual(got.Data, arr.Data) {
+			t.Errorf("Data mismatch. Got %v, want %v", got, arr.Data)
+		}
+	})
+
+	t.Run("npz", func(t *testing.T) {
+		npz := NewNPZFile()
+		Add(npz, "a", arr)
+		Add(npz, "b", arr)
+		path := filepath.Join(tempDir, "bundle.npz")
+		if err := WriteNPZFile(path, npz); err != nil {
+			t.Fatalf("Failed to write .npz file: %v", err)
+		}
+		loaded, err := Load(path)
+		if err != nil {
+			t.Fatalf("Failed to load .npz file: %v", err)
+		}
+		if keys := Keys(loaded); !reflect.DeepEqual(keys, []string{"a", "b"}) {
+			t.Errorf("Keys mismatch. Got %v, want %v", keys, []string{"a", "b"})
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		path := filepath.Join(tempDir, "table.csv")
+		if err := os.WriteFile(path, []byte("1,2\n3,4.5\n"), 0644); err != nil {
+			t.Fatalf("Failed to write .csv file: %v", err)
+		}
+		npz, err := Load(path)
+		if err != nil {
+			t.Fatalf("Failed to load .csv file: %v", err)
+		}
+		got, ok := Get[float64](npz, "table")
+		if !ok {
+			t.Fatalf("Expected table entry, got keys %v", Keys(npz))
+		}
+		if expected := []float64{1, 2, 3, 4.5}; !reflect.DeepEqual(got.Data, expected) {
+			t.Errorf("Data mismatch. Got %v, want %v", got.Data, expected)
+		}
+		if !reflect.DeepEqual(got.Shape, []int{2, 2}) {
+			t.Errorf("Shape mismatch. Got %v, want %v", got.Shape, []int{2, 2})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		if _, err := Load(filepath.Join(tempDir, "data.txt")); err == nil {
+			t.Error("Expected error for unsupported extension, got nil")
+		}
+	})
+}
+
+// TestDetectFormat tests identifying .npy and .npz content by signature
+func TestDetectFormat(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	arr := &Array[float64]{Data: []float64{1, 2}, Shape: []int{2}, DType: Float64}
+
+	// Write an array, an archive and an empty archive
+	npyPath := filepath.Join(tempDir, "a.npy")
+	if err := WriteFile(npyPath, arr); err != nil {
+		t.Fatalf("Failed to write .npy file: %v", err)
+	}
+	npz := NewNPZFile()
+	Add(npz, "a", arr)
+	npzPath := filepath.Join(tempDir, "b.npz")
+	if err := WriteNPZFile(npzPath, npz); err != nil {
+		t.Fatalf("Failed to write .npz file: %v", err)
+	}
+	emptyPath := filepath.Join(tempDir, "empty.npz")
+	if err := WriteNPZFile(emptyPath, NewNPZFile()); err != nil {
+		t.Fatalf("Failed to write .npz file: %v", err)
+	}
+
+	for path, expected := range map[string]string{npyPath: "npy", npzPath: "npz", emptyPath: "npz"} {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", path, err)
+		}
+		format, err := DetectFormat(f)
+		f.Close()
+		if err != nil {
+			t.Errorf("Failed to detect format of %s: %v", path, err)
+		} else if format != expected {
+			t.Errorf("Format mismatch for %s. Got %s, want %s", path, format, expected)
+		}
+	}
+
+	// Unknown and truncated content is rejected
+	for _, data := range [][]byte{[]byte("a,b,c\n"), []byte("PK"), nil} {
+		if format, err := DetectFormat(bytes.NewReader(data)); err == nil {
+			t.Errorf("Expected error for %q, got %s", data, format)
+		}
+	}
+}
+
+// TestOpenAuto tests loading .npy and .npz content from a stream
+func TestOpenAuto(t *testing.T) {
+	arr := &Array[int16]{Data: []int16{1, -2, 3}, Shape: []int{3}, DType: Int16}
+
+	// pipe streams the output of write through an io.Pipe
+	pipe := func(write func(io.Writer) error) io.Reader {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(write(pw))
+		}()
+		return pr
+	}
+
+	t.Run("npy", func(t *testing.T) {
+		loaded, err := OpenAuto(pipe(func(w io.Writer) error { return Write(w, arr) }))
+		if err != nil {
+			t.Fatalf("Failed to open stream: %v", err)
+		}
+		got, ok := loaded.(*Array[int16])
+		if !ok {
+			t.Fatalf("Expected *Array[int16], got %T", loaded)
+		}
+		if !reflect.DeepEqual(got.Data, arr.Data) {
+			t.Errorf("Data mismatch. Got %v, want %v", got.Data, arr.Data)
+		}
+	})
+
+	t.Run("npz", func(t *testing.T) {
+		// Write the archive to a temporary file and stream it back
+		tempDir, err := os.MkdirTemp("", "npy-test")
+		if err != nil {
+			t.Fatalf("Failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		npz := NewNPZFile()
+		Add(npz, "values", arr)
+		path := filepath.Join(tempDir, "bundle.npz")
+		if err := WriteNPZFile(path, npz); err != nil {
+			t.Fatalf("Failed to write NPZ file: %v", err)
+		}
+
+		loaded, err := OpenAuto(pipe(func(w io.Writer) error {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(w, f)
+			return err
+		}))
+		if err != nil {
+			t.Fatalf("Failed to open stream: %v", err)
+		}
+		got, ok := loaded.(*NPZFile)
+		if !ok {
+			t.Fatalf("Expected *NPZFile, got %T", loaded)
+		}
+		values, ok := Get[int16](got, "values")
+		if !ok || !reflect.DeepEqual(values.Data, arr.Data) {
+			t.Errorf("Data mismatch. Got %v, want %v", values, arr.Data)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if _, err := OpenAuto(bytes.NewReader([]byte("hello"))); err == nil {
+			t.Error("Expected error for unrecognized stream, got nil")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package npy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// AppendMarshal appends the .npy encoding of arr to dst and returns the
+// extended buffer, reusing dst's capacity where possible
+func AppendMarshal[T any](dst []byte, arr *Array[T]) ([]byte, error) {
+	buf := bytes.NewBuffer(dst)
+	if err := Write(buf, arr); err != nil {
+		return dst, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadFrom decodes a complete .npy stream from r into the array, replacing
+// its Data, Shape, DType and Fortran fields, and returns the number of bytes
+// consumed. Unlike most io.ReaderFrom implementations it stops at the end of
+// the array rather than reading until EOF.
+func (a *Array[T]) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	arr, err := Read[T](cr)
+	if err != nil {
+		return cr.n, err
+	}
+
+	*a = *arr
+	return cr.n, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the .npy encoding
+func (a *Array[T]) MarshalBinary() ([]byte, error) {
+	return AppendMarshal(nil, a)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding a complete
+// .npy encoding into the array
+func (a *Array[T]) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	arr, err := Read[T](r)
+	if err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("unexpected %d bytes after array data", r.Len())
+	}
+
+	*a = *arr
+	return nil
+}
+
+// Fingerprint returns the hex-encoded SHA-256 of the array's .npy encoding.
+// Arrays with equal data, shape, dtype and order have equal fingerprints. It
+// returns an empty string if the array cannot be encoded.
+func (a *Array[T]) Fingerprint() string {
+	// Hash the canonical encoding rather than any descriptor, version or byte
+	// order the array was read with
+	canonical := *a
+	canonical.descr = ""
+	canonical.Version = [2]uint8{}
+	canonical.ByteOrder = nil
+
+	h := sha256.New()
+	if err := Write(h, &canonical); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
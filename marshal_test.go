@@ -0,0 +1,199 @@
+package npy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// TestAppendMarshal tests appending two arrays into one buffer
+func TestAppendMarshal(t *testing.T) {
+	// Create test arrays
+	arr1 := &Array[float64]{
+		Data:    []float64{1.5, 2.5, 3.5},
+		Shape:   []int{3},
+		DType:   Float64,
+		Fortran: false,
+	}
+	arr2 := &Array[float64]{
+		Data:    []float64{4.0, 5.0, 6.0, 7.0},
+		Shape:   []int{2, 2},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	// Append both arrays to a buffer with a prefix
+	prefix := []byte("prefix")
+	dst := append(make([]byte, 0, 1024), prefix...)
+	dst, err := AppendMarshal(dst, arr1)
+	if err != nil {
+		t.Fatalf("Failed to marshal first array: %v", err)
+	}
+	dst, err = AppendMarshal(dst, arr2)
+	if err != nil {
+		t.Fatalf("Failed to marshal second array: %v", err)
+	}
+
+	// Verify the prefix is preserved
+	if !bytes.HasPrefix(dst, prefix) {
+		t.Fatalf("Prefix was not preserved")
+	}
+
+	// Decode both arrays in sequence
+	r := bytes.NewReader(dst[len(prefix):])
+	readArr1, err := Read[float64](r)
+	if err != nil {
+		t.Fatalf("Failed to read first array: %v", err)
+	}
+	if !reflect.DeepEqual(readArr1.Data, arr1.Data) {
+		t.Errorf("Data mismatch for first array. Got %v, want %v", readArr1.Data, arr1.Data)
+	}
+
+	readArr2, err := Read[float64](r)
+	if err != nil {
+		t.Fatalf("Failed to read second array: %v", err)
+	}
+	if !reflect.DeepEqual(readArr2.Shape, arr2.Shape) {
+		t.Errorf("Shape mismatch for second array. Got %v, want %v", readArr2.Shape, arr2.Shape)
+	}
+
+	// Verify a failed marshal leaves dst untouched
+	bad := &Array[float64]{Data: []float64{1}, Shape: []int{2}, DType: Float64}
+	out, err := AppendMarshal(prefix, bad)
+	if err == nil {
+		t.Error("Expected error for invalid array, got nil")
+	}
+	if !bytes.Equal(out, prefix) {
+		t.Errorf("Expected dst to be returned unchanged, got %q", out)
+	}
+}
+
+// TestReadFrom tests populating an existing Array value from a reader
+func TestReadFrom(t *testing.T) {
+	// Create test array
+	arr := &Array[int64]{
+		Data:    []int64{10, 20, 30, 40, 50, 60},
+		Shape:   []int{3, 2},
+		DType:   Int64,
+		Fortran: true,
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	size := int64(buf.Len())
+
+	// Add trailing bytes that must not be consumed
+	buf.Write([]byte("trailer"))
+
+	// Read into a pre-allocated value
+	var readArr Array[int64]
+	n, err := readArr.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+
+	// Verify byte count and remaining data
+	if n != size {
+		t.Errorf("Byte count mismatch. Got %d, want %d", n, size)
+	}
+	if buf.String() != "trailer" {
+		t.Errorf("Expected trailing bytes to remain, got %q", buf.String())
+	}
+
+	// Verify fields
+	if !reflect.DeepEqual(readArr.Data, arr.Data) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, arr.Data)
+	}
+	if !reflect.DeepEqual(readArr.Shape, arr.Shape) {
+		t.Errorf("Shape mismatch. Got %v, want %v", readArr.Shape, arr.Shape)
+	}
+	if readArr.DType != arr.DType || readArr.Fortran != arr.Fortran {
+		t.Errorf("Metadata mismatch. Got %v/%v, want %v/%v", readArr.DType, readArr.Fortran, arr.DType, arr.Fortran)
+	}
+
+	// Verify Array satisfies io.ReaderFrom
+	var _ io.ReaderFrom = &readArr
+}
+
+// TestFingerprint tests that equal arrays share a fingerprint and different arrays don't
+func TestFingerprint(t *testing.T) {
+	// Create two equal but distinct arrays
+	arr1 := &Array[float64]{Data: []float64{1, 2, 3, 4}, Shape: []int{2, 2}, DType: Float64}
+	arr2 := &Array[float64]{Data: []float64{1, 2, 3, 4}, Shape: []int{2, 2}, DType: Float64}
+
+	fp1 := arr1.Fingerprint()
+	if len(fp1) != 64 {
+		t.Fatalf("Expected 64 hex characters, got %q", fp1)
+	}
+	if fp2 := arr2.Fingerprint(); fp1 != fp2 {
+		t.Errorf("Fingerprint mismatch for equal arrays. Got %s, want %s", fp2, fp1)
+	}
+
+	// Changing an element must change the fingerprint
+	arr2.Data[3] = 5
+	if fp2 := arr2.Fingerprint(); fp1 == fp2 {
+		t.Errorf("Expected different fingerprint after changing data")
+	}
+
+	// So must changing the shape
+	arr3 := &Array[float64]{Data: []float64{1, 2, 3, 4}, Shape: []int{4}, DType: Float64}
+	if fp3 := arr3.Fingerprint(); fp1 == fp3 {
+		t.Errorf("Expected different fingerprint after changing shape")
+	}
+
+	// Invalid arrays have no fingerprint
+	invalid := &Array[float64]{Data: []float64{1, 2}, Shape: []int{3}, DType: Float64}
+	if fp := invalid.Fingerprint(); fp != "" {
+		t.Errorf("Expected empty fingerprint for invalid array, got %s", fp)
+	}
+}
+
+// TestMarshalBinary tests round-tripping through MarshalBinary and UnmarshalBinary
+func TestMarshalBinary(t *testing.T) {
+	// Create test array
+	arr := &Array[int32]{
+		Data:    []int32{1, 2, 3, 4, 5, 6},
+		Shape:   []int{3, 2},
+		DType:   Int32,
+		Fortran: true,
+	}
+
+	data, err := arr.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed to marshal array: %v", err)
+	}
+
+	var decoded Array[int32]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Failed to unmarshal array: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Data, arr.Data) || !reflect.DeepEqual(decoded.Shape, arr.Shape) || decoded.Fortran != arr.Fortran {
+		t.Errorf("Array mismatch. Got %+v, want %+v", decoded, *arr)
+	}
+
+	// Trailing data is rejected
+	if err := decoded.UnmarshalBinary(append(data, 0)); err == nil {
+		t.Error("Expected error for trailing data, got nil")
+	}
+
+	// Arrays embedded in gob-encoded structures use the binary encoding
+	type record struct {
+		Name  string
+		Array *Array[int32]
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(record{Name: "x", Array: arr}); err != nil {
+		t.Fatalf("Failed to gob-encode record: %v", err)
+	}
+	var got record
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("Failed to gob-decode record: %v", err)
+	}
+	if got.Name != "x" || !reflect.DeepEqual(got.Array.Data, arr.Data) || !reflect.DeepEqual(got.Array.Shape, arr.Shape) {
+		t.Errorf("Record mismatch. Got %+v", got)
+	}
+}
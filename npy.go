@@ -3,13 +3,21 @@ package npy
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unsafe"
 )
 
 // DType represents NumPy data types
@@ -30,25 +38,133 @@ const (
 	Float64 DType = "float64"
 )
 
+// ItemSize returns the size in bytes of a single element of the dtype, or 0
+// for an unknown dtype
+func (d DType) ItemSize() int {
+	switch d {
+	case Bool, Int8, Uint8:
+		return 1
+	case Int16, Uint16:
+		return 2
+	case Int32, Uint32, Float32:
+		return 4
+	case Int64, Uint64, Float64:
+		return 8
+	default:
+		if custom, ok := lookupDType(d); ok {
+			return custom.itemSize
+		}
+		return 0
+	}
+}
+
+// Descriptor returns the canonical NumPy descriptor for the dtype in the given
+// byte order, such as "<f8" or "|b1". A nil order means little-endian. It
+// returns an empty string for an unknown dtype.
+func (d DType) Descriptor(order binary.ByteOrder) string {
+	var code string
+	switch d {
+	case Bool:
+		code = "b1"
+	case Int8:
+		code = "i1"
+	case Int16:
+		code = "i2"
+	case Int32:
+		code = "i4"
+	case Int64:
+		code = "i8"
+	case Uint8:
+		code = "u1"
+	case Uint16:
+		code = "u2"
+	case Uint32:
+		code = "u4"
+	case Uint64:
+		code = "u8"
+	case Float32:
+		code = "f4"
+	case Float64:
+		code = "f8"
+	default:
+		// Registered dtypes are always stored little-endian
+		custom, ok := lookupDType(d)
+		if !ok {
+			return ""
+		}
+		if custom.itemSize == 1 {
+			return "|" + string(d)
+		}
+		return "<" + string(d)
+	}
+
+	// Byte order is meaningless for single-byte types
+	switch {
+	case d.ItemSize() == 1:
+		return "|" + code
+	case order != nil && isBigEndian(order):
+		return ">" + code
+	default:
+		return "<" + code
+	}
+}
+
+// MaxNPZEntrySize is the largest entry, in bytes, that ReadNPZFile will
+// decode. It guards against archives whose headers declare huge arrays.
+var MaxNPZEntrySize int64 = 8 << 30
+
+// MaxHeaderSize is the largest header, in bytes, that will be read. Like
+// NumPy's max_header_size it guards against files declaring huge headers.
+var MaxHeaderSize = 10000
+
 // Array represents a NumPy array with type parameter for data
 type Array[T any] struct {
 	Data    []T
 	Shape   []int
 	DType   DType
 	Fortran bool // True if array is in Fortran order (column-major)
+
+	// Version is the format version, major then minor, of the file the array
+	// was read from. It is zero for arrays that were not read. Writing always
+	// uses format 1, keeping the minor version of an array read from a 1.x file.
+	Version [2]uint8
+
+	// ByteOrder is the byte order of the data in the file the array was read
+	// from. Data is always decoded to host order; writing uses ByteOrder
+	// unless WriteOptions.ByteOrder is set. Nil means little-endian.
+	ByteOrder binary.ByteOrder
+
+	descr string // Descriptor the array was read with, if any
 }
 
 // header represents the metadata in a NumPy file
 type header struct {
-	Shape   []int
-	DType   DType
-	Fortran bool
+	Shape     []int
+	DType     DType
+	Fortran   bool
+	ByteOrder binary.ByteOrder
+	Descr     string   // Descriptor exactly as written in the file
+	Version   [2]uint8 // Format version of the file
+}
+
+// ReadOptions controls how arrays are decoded when reading
+type ReadOptions struct {
+	ForceCOrder bool // Convert Fortran-order data to C order on read
+
+	// SkipExtensionCheck lets ReadFileWithOptions open paths that don't end
+	// in .npy, relying on the magic string to validate the format
+	SkipExtensionCheck bool
 }
 
 // ReadFile reads a NumPy array from a .npy file with the specified type
 func ReadFile[T any](path string) (*Array[T], error) {
+	return ReadFileWithOptions[T](path, ReadOptions{})
+}
+
+// ReadFileWithOptions reads a NumPy array from a .npy file using the given options
+func ReadFileWithOptions[T any](path string, opts ReadOptions) (*Array[T], error) {
 	// Check file extension to ensure we're reading a .npy file
-	if !strings.HasSuffix(path, ".npy") {
+	if !opts.SkipExtensionCheck && !strings.HasSuffix(path, ".npy") {
 		return nil, fmt.Errorf("expected .npy file extension, got %s", path)
 	}
 
@@ -58,13 +174,18 @@ func ReadFile[T any](path string) (*Array[T], error) {
 	}
 	defer f.Close()
 
-	return Read[T](f)
+	return ReadWithOptions[T](f, opts)
 }
 
 // WriteFile writes a NumPy array to a .npy file
 func WriteFile[T any](path string, arr *Array[T]) error {
+	return WriteFileWithOptions(path, arr, WriteOptions{})
+}
+
+// WriteFileWithOptions writes a NumPy array to a .npy file using the given options
+func WriteFileWithOptions[T any](path string, arr *Array[T], opts WriteOptions) error {
 	// Ensure correct file extension
-	if !strings.HasSuffix(path, ".npy") {
+	if !opts.SkipExtensionCheck && !strings.HasSuffix(path, ".npy") {
 		path += ".npy" // Automatically add extension if missing
 	}
 
@@ -74,12 +195,26 @@ func WriteFile[T any](path string, arr *Array[T]) error {
 	}
 	defer f.Close()
 
-	return Write(f, arr)
+	return WriteWithOptions(f, arr, opts)
 }
 
 // NPZFile represents a NumPy .npz file containing multiple arrays
 type NPZFile struct {
 	arrays map[string]interface{}
+
+	// Description and Created are optional provenance details, stored in a
+	// metadata.json entry alongside the arrays when either is set
+	Description string
+	Created     time.Time
+}
+
+// npzMetadataName is the archive entry holding NPZFile metadata
+const npzMetadataName = "metadata.json"
+
+// npzMetadata is the JSON encoding of NPZFile metadata
+type npzMetadata struct {
+	Description string     `json:"description,omitempty"`
+	Created     *time.Time `json:"created,omitempty"`
 }
 
 // NewNPZFile creates a new empty NPZ file
@@ -89,14 +224,32 @@ func NewNPZFile() *NPZFile {
 	}
 }
 
-// Add adds an array to the NPZ file
+// Add adds an array to the NPZ file, replacing any array with the same name.
+// A trailing .npy is removed from the name, so "x" and "x.npy" are the same entry.
 func Add[T any](npz *NPZFile, name string, arr *Array[T]) {
-	npz.arrays[name] = arr
+	npz.arrays[npzEntryName(name)] = arr
+}
+
+// AddChecked adds an array to the NPZ file like Add, but returns an error
+// instead of replacing an existing array with the same normalized name
+func AddChecked[T any](npz *NPZFile, name string, arr *Array[T]) error {
+	key := npzEntryName(name)
+	if _, exists := npz.arrays[key]; exists {
+		return fmt.Errorf("array name %q collides with existing entry %q", name, key)
+	}
+	npz.arrays[key] = arr
+	return nil
 }
 
-// Get retrieves an array from the NPZ file
+// npzEntryName normalizes an array name to the key used in NPZFile
+func npzEntryName(name string) string {
+	return strings.TrimSuffix(name, ".npy")
+}
+
+// Get retrieves an array from the NPZ file. The name may be given with or
+// without a trailing .npy.
 func Get[T any](npz *NPZFile, name string) (*Array[T], bool) {
-	val, ok := npz.arrays[name]
+	val, ok := npz.arrays[npzEntryName(name)]
 	if !ok {
 		return nil, false
 	}
@@ -105,443 +258,1185 @@ func Get[T any](npz *NPZFile, name string) (*Array[T], bool) {
 	return arr, ok
 }
 
-// Keys returns the names of all arrays in the NPZ file
+// GetAny retrieves an array from the NPZ file without asserting its element
+// type. The result is an *Array[T] for the entry's element type.
+func GetAny(npz *NPZFile, name string) (interface{}, bool) {
+	val, ok := npz.arrays[npzEntryName(name)]
+	return val, ok
+}
+
+// Keys returns the names of all arrays in the NPZ file in sorted order
 func Keys(npz *NPZFile) []string {
 	keys := make([]string, 0, len(npz.arrays))
 	for k := range npz.arrays {
 		keys = append(keys, k)
 	}
+	slices.Sort(keys)
 	return keys
 }
 
-// ReadNPZFile reads multiple NumPy arrays from a .npz file
-func ReadNPZFile(path string) (*NPZFile, error) {
-	// Check file extension
-	if !strings.HasSuffix(path, ".npz") {
-		return nil, fmt.Errorf("expected .npz file extension, got %s", path)
+// GetAll returns every array in the NPZ file whose element type is T, keyed by name
+func GetAll[T any](npz *NPZFile) map[string]*Array[T] {
+	arrays := make(map[string]*Array[T])
+	for name, val := range npz.arrays {
+		if arr, ok := val.(*Array[T]); ok {
+			arrays[name] = arr
+		}
+	}
+	return arrays
+}
+
+// View returns a typed map of every array in the NPZ file whose element type
+// is T, so repeated lookups need no type assertions. It is equivalent to
+// GetAll; the map is new but the arrays are shared with npz.
+func View[T any](npz *NPZFile) map[string]*Array[T] {
+	return GetAll[T](npz)
+}
+
+// GetAllBool returns every bool array in the NPZ file, keyed by name
+func GetAllBool(npz *NPZFile) map[string]*Array[bool] {
+	return GetAll[bool](npz)
+}
+
+// GetAllInt8 returns every int8 array in the NPZ file, keyed by name
+func GetAllInt8(npz *NPZFile) map[string]*Array[int8] {
+	return GetAll[int8](npz)
+}
+
+// GetAllInt16 returns every int16 array in the NPZ file, keyed by name
+func GetAllInt16(npz *NPZFile) map[string]*Array[int16] {
+	return GetAll[int16](npz)
+}
+
+// GetAllInt32 returns every int32 array in the NPZ file, keyed by name
+func GetAllInt32(npz *NPZFile) map[string]*Array[int32] {
+	return GetAll[int32](npz)
+}
+
+// GetAllInt64 returns every int64 array in the NPZ file, keyed by name
+func GetAllInt64(npz *NPZFile) map[string]*Array[int64] {
+	return GetAll[int64](npz)
+}
+
+// GetAllUint8 returns every uint8 array in the NPZ file, keyed by name
+func GetAllUint8(npz *NPZFile) map[string]*Array[uint8] {
+	return GetAll[uint8](npz)
+}
+
+// GetAllUint16 returns every uint16 array in the NPZ file, keyed by name
+func GetAllUint16(npz *NPZFile) map[string]*Array[uint16] {
+	return GetAll[uint16](npz)
+}
+
+// GetAllUint32 returns every uint32 array in the NPZ file, keyed by name
+func GetAllUint32(npz *NPZFile) map[string]*Array[uint32] {
+	return GetAll[uint32](npz)
+}
+
+// GetAllUint64 returns every uint64 array in the NPZ file, keyed by name
+func GetAllUint64(npz *NPZFile) map[string]*Array[uint64] {
+	return GetAll[uint64](npz)
+}
+
+// GetAllFloat32 returns every float32 array in the NPZ file, keyed by name
+func GetAllFloat32(npz *NPZFile) map[string]*Array[float32] {
+	return GetAll[float32](npz)
+}
+
+// GetAllFloat64 returns every float64 array in the NPZ file, keyed by name
+func GetAllFloat64(npz *NPZFile) map[string]*Array[float64] {
+	return GetAll[float64](npz)
+}
+
+// NewNPZFromDir creates an NPZ file from every .npy file in dir, keyed by
+// file name without the extension. Subdirectories and other files are ignored.
+func NewNPZFromDir(dir string) (*NPZFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	npz := NewNPZFile()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".npy") {
+			continue
+		}
+
+		array, err := readAnyFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		npz.arrays[npzEntryName(entry.Name())] = array
+	}
+
+	return npz, nil
+}
+
+// readAnyFile reads a NumPy array of any supported dtype from a file
+func readAnyFile(path string) (interface{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	return readAny(f, ReadOptions{})
+}
+
+// ReadNPZFile reads multiple NumPy arrays from a .npz file. Arrays are keyed
+// by entry name with any trailing .npy removed, whether or not the archive
+// stored the suffix. An archive with no array entries reads as an empty
+// NPZFile rather than an error.
+func ReadNPZFile(path string) (*NPZFile, error) {
+	// Check file extension
+	if !strings.HasSuffix(path, ".npz") {
+		return nil, fmt.Errorf("expected .npz file extension, got %s", path)
+	}
+
+	// Open the zip file
+	zipReader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open NPZ file: %w", err)
+	}
+	defer zipReader.Close()
+
+	return readNPZ(&zipReader.Reader, nil, nil)
+}
+
+// ReadNPZFileLenient reads a .npz file like ReadNPZFile, but keeps going when
+// an entry cannot be decoded. It returns the arrays that were read along with
+// the failures keyed by entry name; the error is only set if the archive
+// itself cannot be opened.
+func ReadNPZFileLenient(path string) (*NPZFile, map[string]error, error) {
+	// Check file extension
+	if !strings.HasSuffix(path, ".npz") {
+		return nil, nil, fmt.Errorf("expected .npz file extension, got %s", path)
+	}
+
+	// Open the zip file
+	zipReader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open NPZ file: %w", err)
+	}
+	defer zipReader.Close()
+
+	errs := make(map[string]error)
+	npz, err := readNPZ(&zipReader.Reader, nil, errs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return npz, errs, nil
+}
+
+// ReadNPZFileFiltered reads the arrays in a .npz file for which want returns
+// true. Each entry's header is read first and passed to want, so entries that
+// are not wanted are never decoded.
+func ReadNPZFileFiltered(path string, want func(name string, dtype DType, shape []int) bool) (*NPZFile, error) {
+	// Check file extension
+	if !strings.HasSuffix(path, ".npz") {
+		return nil, fmt.Errorf("expected .npz file extension, got %s", path)
+	}
+
+	// Open the zip file
+	zipReader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open NPZ file: %w", err)
+	}
+	defer zipReader.Close()
+
+	return readNPZ(&zipReader.Reader, want, nil)
+}
+
+// ReadNPZ reads multiple NumPy arrays from an in-memory or on-disk .npz
+// archive of the given size
+func ReadNPZ(r io.ReaderAt, size int64) (*NPZFile, error) {
+	zipReader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open NPZ archive: %w", err)
+	}
+
+	return readNPZ(zipReader, nil, nil)
+}
+
+// NPZBufferOptions controls how a streamed .npz archive is buffered, since
+// zip decoding requires random access. The zero value buffers the whole
+// archive in memory.
+type NPZBufferOptions struct {
+	TempFile  bool   // Buffer to a temporary file instead of memory
+	TempDir   string // Directory for the temporary file; defaults to os.TempDir
+	MaxMemory int64  // Largest archive buffered in memory, in bytes; 0 means no limit
+}
+
+// ReadNPZStream reads multiple NumPy arrays from a .npz archive streamed from
+// r, buffering it as described by opts
+func ReadNPZStream(r io.Reader, opts NPZBufferOptions) (*NPZFile, error) {
+	if !opts.TempFile {
+		lr := r
+		if opts.MaxMemory > 0 {
+			lr = io.LimitReader(r, opts.MaxMemory+1)
+		}
+		data, err := io.ReadAll(lr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to buffer NPZ archive: %w", err)
+		}
+		if opts.MaxMemory > 0 && int64(len(data)) > opts.MaxMemory {
+			return nil, fmt.Errorf("NPZ archive exceeds in-memory limit of %d bytes", opts.MaxMemory)
+		}
+		return ReadNPZ(bytes.NewReader(data), int64(len(data)))
+	}
+
+	f, err := os.CreateTemp(opts.TempDir, "npy-*.npz")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer NPZ archive: %w", err)
+	}
+	return ReadNPZ(f, size)
+}
+
+// readNPZ decodes the arrays in an opened zip archive, skipping entries for
+// which want returns false. A nil want decodes every entry. If errs is nil the
+// first failing entry aborts the read; otherwise failures are recorded in errs
+// by entry name and the remaining entries are still decoded.
+func readNPZ(zipReader *zip.Reader, want func(name string, dtype DType, shape []int) bool, errs map[string]error) (*NPZFile, error) {
+	// Create NPZ file
+	npz := NewNPZFile()
+
+	// Process each file in the zip
+	for _, f := range zipReader.File {
+		// Skip directories
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		// Metadata is not an array
+		if f.Name == npzMetadataName {
+			if err := readNPZMetadata(f, npz); err != nil {
+				if errs == nil {
+					return nil, err
+				}
+				errs[f.Name] = err
+			}
+			continue
+		}
+
+		// Extract name
+		name := npzEntryName(f.Name)
+
+		array, err := readNPZEntry(f, name, want)
+		if err != nil {
+			if errs == nil {
+				return nil, err
+			}
+			errs[name] = err
+			continue
+		}
+		if array != nil {
+			npz.arrays[name] = array
+		}
+	}
+
+	return npz, nil
+}
+
+// readNPZEntry decodes a single archive entry, returning a nil array if want
+// rejects it
+func readNPZEntry(f *zip.File, name string, want func(name string, dtype DType, shape []int) bool) (interface{}, error) {
+	// Open the file
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s in NPZ: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	// We need to determine the type of the array before we can read it,
+	// so read the header first to peek at the dtype
+	hdr, preambleLen, err := readHeader(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header from %s: %w", f.Name, err)
+	}
+
+	// Skip unwanted entries before allocating anything for their data
+	if want != nil && !want(name, hdr.DType, hdr.Shape) {
+		return nil, nil
+	}
+
+	// Make sure the declared data fits in the entry before allocating for it
+	entrySize, err := hdr.dataSize()
+	if err == nil {
+		entrySize += int64(preambleLen)
+		if entrySize > MaxNPZEntrySize {
+			err = fmt.Errorf("entry size %d exceeds limit of %d bytes", entrySize, MaxNPZEntrySize)
+		} else if uint64(entrySize) > f.UncompressedSize64 {
+			err = fmt.Errorf("header declares %d bytes but entry holds %d", entrySize, f.UncompressedSize64)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry %s: %w", f.Name, err)
+	}
+
+	// Read array based on dtype, never consuming more than the declared entry size
+	lr := io.LimitReader(rc, entrySize-int64(preambleLen))
+	array, err := readBodyAny(lr, hdr, ReadOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s array from %s: %w", hdr.DType, f.Name, err)
+	}
+	return array, nil
+}
+
+// readNPZMetadata decodes the metadata entry of an archive into npz
+func readNPZMetadata(f *zip.File, npz *NPZFile) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open file %s in NPZ: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	var meta npzMetadata
+	if err := json.NewDecoder(rc).Decode(&meta); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", f.Name, err)
+	}
+
+	npz.Description = meta.Description
+	if meta.Created != nil {
+		npz.Created = *meta.Created
+	}
+	return nil
+}
+
+// writeNPZMetadata adds a metadata entry to the archive if npz has any metadata
+func writeNPZMetadata(zipWriter *zip.Writer, npz *NPZFile) error {
+	if npz.Description == "" && npz.Created.IsZero() {
+		return nil
+	}
+
+	meta := npzMetadata{Description: npz.Description}
+	if !npz.Created.IsZero() {
+		meta.Created = &npz.Created
+	}
+
+	w, err := zipWriter.Create(npzMetadataName)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s in NPZ: %w", npzMetadataName, err)
+	}
+	if err := json.NewEncoder(w).Encode(meta); err != nil {
+		return fmt.Errorf("failed to write %s: %w", npzMetadataName, err)
+	}
+	return nil
+}
+
+// NPZWriteOptions controls how .npz archives are written
+type NPZWriteOptions struct {
+	// CompressionLevel is the flate level used for each entry, from
+	// flate.HuffmanOnly to flate.BestCompression. Note that the zero value is
+	// flate.NoCompression; use flate.DefaultCompression for the default.
+	CompressionLevel int
+}
+
+// WriteNPZFile writes multiple NumPy arrays to a .npz file. An NPZFile with no
+// arrays is written as a valid archive with no array entries.
+func WriteNPZFile(path string, npz *NPZFile) error {
+	return WriteNPZFileWithOptions(path, npz, NPZWriteOptions{
+		CompressionLevel: flate.DefaultCompression,
+	})
+}
+
+// WriteNPZFileWithOptions writes multiple NumPy arrays to a .npz file using the given options
+func WriteNPZFileWithOptions(path string, npz *NPZFile, opts NPZWriteOptions) error {
+	// Validate compression level before creating anything on disk
+	if opts.CompressionLevel < flate.HuffmanOnly || opts.CompressionLevel > flate.BestCompression {
+		return fmt.Errorf("invalid compression level: %d", opts.CompressionLevel)
+	}
+
+	// Ensure correct file extension
+	if !strings.HasSuffix(path, ".npz") {
+		path += ".npz" // Automatically add extension if missing
+	}
+
+	// Create the zip file
+	zipFile, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create NPZ file: %w", err)
+	}
+	defer zipFile.Close()
+
+	// Create zip writer
+	zipWriter := zip.NewWriter(zipFile)
+	defer zipWriter.Close()
+
+	// Use the requested compression level for deflated entries
+	zipWriter.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, opts.CompressionLevel)
+	})
+
+	// Write each array to the zip, in name order so output is reproducible
+	for _, name := range Keys(npz) {
+		array := npz.arrays[name]
+
+		// Ensure name has .npy extension
+		if !strings.HasSuffix(name, ".npy") {
+			name += ".npy"
+		}
+
+		// Create file in zip
+		w, err := zipWriter.Create(name)
+		if err != nil {
+			return fmt.Errorf("failed to create file %s in NPZ: %w", name, err)
+		}
+
+		// Write array based on type
+		if err := writeAny(w, array); err != nil {
+			return fmt.Errorf("failed to write array to %s: %w", name, err)
+		}
+	}
+
+	return writeNPZMetadata(zipWriter, npz)
+}
+
+// ExtractNPZToDir writes each array in a .npz file to outDir as name.npy. The
+// directory is created even if the archive holds no arrays.
+func ExtractNPZToDir(npzPath, outDir string) error {
+	npz, err := ReadNPZFile(npzPath)
+	if err != nil {
+		return err
+	}
+
+	// Refuse to write anything if an entry would land outside outDir
+	paths := make(map[string]string, len(npz.arrays))
+	for name := range npz.arrays {
+		path, err := entryOutputPath(outDir, name, ".npy")
+		if err != nil {
+			return err
+		}
+		paths[name] = path
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for name, array := range npz.arrays {
+		if err := writeAnyFile(paths[name], array); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// entryOutputPath returns the file in dir for the archive entry name with the
+// given extension, rejecting names that are absolute, contain .. or otherwise
+// escape dir
+func entryOutputPath(dir, name, ext string) (string, error) {
+	if !filepath.IsLocal(name + ext) {
+		return "", fmt.Errorf("unsafe entry name %q", name)
+	}
+	return filepath.Join(dir, name+ext), nil
+}
+
+// writeAnyFile writes an array of any supported type to a .npy file
+func writeAnyFile(path string, array interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if err := writeAny(f, array); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// writeAny writes an array held in an untyped value, dispatching on its
+// element type
+func writeAny(w io.Writer, array interface{}) error {
+	switch arr := array.(type) {
+	case *Array[bool]:
+		return Write(w, arr)
+	case *Array[int8]:
+		return Write(w, arr)
+	case *Array[int16]:
+		return Write(w, arr)
+	case *Array[int32]:
+		return Write(w, arr)
+	case *Array[int64]:
+		return Write(w, arr)
+	case *Array[uint8]:
+		return Write(w, arr)
+	case *Array[uint16]:
+		return Write(w, arr)
+	case *Array[uint32]:
+		return Write(w, arr)
+	case *Array[uint64]:
+		return Write(w, arr)
+	case *Array[float32]:
+		return Write(w, arr)
+	case *Array[float64]:
+		return Write(w, arr)
+	default:
+		return fmt.Errorf("unsupported array type %T", array)
+	}
+}
+
+// readData reads the actual data from the file based on the header information
+func readData[T any](r io.Reader, hdr *header) ([]T, error) {
+	// Calculate total number of elements, rejecting shapes whose element or
+	// byte count would overflow
+	if _, err := hdr.dataSize(); err != nil {
+		return nil, err
+	}
+	totalElements, err := shapeElements(hdr.Shape)
+	if err != nil {
+		return nil, err
+	}
+
+	// Registered dtypes decode themselves
+	if custom, ok := lookupDType(hdr.DType); ok {
+		vals, err := custom.read(r, totalElements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s data: %w", hdr.DType, err)
+		}
+		data, ok := vals.([]T)
+		if !ok {
+			return nil, fmt.Errorf("dtype %s decoded to %T, not %T", hdr.DType, vals, data)
+		}
+		if len(data) != totalElements {
+			return nil, fmt.Errorf("dtype %s decoded %d elements, want %d", hdr.DType, len(data), totalElements)
+		}
+		return data, nil
+	}
+
+	// Count what was read so a short data section can be explained
+	cr := &countingReader{r: r}
+
+	// Decode in chunks so that memory grows with the bytes that actually
+	// arrive rather than with the size the header claims
+	var zero T
+	chunk := readChunkSize
+	if size := int(unsafe.Sizeof(zero)); size > 0 {
+		chunk = max(readChunkSize/size, 1)
+	}
+	data := make([]T, 0, min(totalElements, chunk))
+	for len(data) < totalElements {
+		start := len(data)
+		n := min(chunk, totalElements-start)
+		data = slices.Grow(data, n)[:start+n]
+
+		// Bools are decoded from raw bytes so that any nonzero value reads as true
+		if bools, ok := any(data[start:]).([]bool); ok {
+			err = readBools(cr, bools)
+		} else {
+			err = binary.Read(cr, hdr.ByteOrder, data[start:])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read data: %w", shortDataError[T](hdr, cr.n, err))
+		}
+	}
+
+	return data, nil
+}
+
+// readChunkSize is the number of bytes readData decodes at a time
+const readChunkSize = 1 << 20
+
+// shortDataError adds a hint to err when a data section of n bytes ended
+// early in a way that suggests the wrong element type or shape
+func shortDataError[T any](hdr *header, n int64, err error) error {
+	if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+
+	var zero T
+	size := int64(unsafe.Sizeof(zero))
+	if itemSize := int64(hdr.DType.ItemSize()); itemSize != size {
+		return fmt.Errorf("%w: %s data read as %T, which is %d bytes per element instead of %d; check the element type", err, hdr.DType, zero, size, itemSize)
+	}
+
+	if len(hdr.Shape) > 0 {
+		outer := hdr.Shape[:len(hdr.Shape)-1]
+		count := 1
+		for _, dim := range outer {
+			count *= dim
+		}
+		if n/size == int64(count) {
+			return fmt.Errorf("%w: data holds %d elements, matching shape %v without its last dimension; check the shape and dtype", err, count, outer)
+		}
+	}
+
+	return err
+}
+
+// writeData writes data in the given byte order. When the order matches the
+// host's, the slice's memory is written directly instead of encoding each
+// element, producing the same bytes as binary.Write.
+func writeData[T any](w io.Writer, order binary.ByteOrder, data []T) error {
+	if dtypeOf[T]() == "" || len(data) == 0 || isBigEndian(order) != isBigEndian(binary.NativeEndian) {
+		return binary.Write(w, order, data)
+	}
+
+	var zero T
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(&data[0])), len(data)*int(unsafe.Sizeof(zero)))
+	_, err := w.Write(raw)
+	return err
+}
+
+// readBools fills data from one byte per element, mapping nonzero bytes to
+// true. Masks produced by bitwise operations may contain values such as 0xFF.
+func readBools(r io.Reader, data []bool) error {
+	raw := make([]byte, len(data))
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return err
+	}
+	for i, b := range raw {
+		data[i] = b != 0
+	}
+	return nil
+}
+
+// toCOrder rearranges column-major (Fortran order) data into row-major (C order)
+// for the given shape
+func toCOrder[T any](data []T, shape []int) []T {
+	out := make([]T, len(data))
+	if len(out) == 0 {
+		return out
+	}
+
+	// In Fortran order the first dimension varies fastest
+	strides := make([]int, len(shape))
+	stride := 1
+	for i, dim := range shape {
+		strides[i] = stride
+		stride *= dim
+	}
+
+	// Walk the coordinates in C order, gathering from the Fortran layout
+	coord := make([]int, len(shape))
+	for i := range out {
+		idx := 0
+		for d, c := range coord {
+			idx += c * strides[d]
+		}
+		out[i] = data[idx]
+
+		for d := len(coord) - 1; d >= 0; d-- {
+			coord[d]++
+			if coord[d] < shape[d] {
+				break
+			}
+			coord[d] = 0
+		}
+	}
+
+	return out
+}
+
+// defaultHeaderKeys is the order in which NumPy writes the header keys
+var defaultHeaderKeys = []string{"descr", "fortran_order", "shape"}
+
+// generateHeader creates a header string for a NumPy array
+func generateHeader[T any](arr *Array[T], order binary.ByteOrder) string {
+	return generateHeaderWithKeys(arr, order, defaultHeaderKeys)
+}
+
+// generateHeaderWithKeys creates a header string for a NumPy array with the
+// dictionary keys in the given order
+func generateHeaderWithKeys[T any](arr *Array[T], order binary.ByteOrder, keys []string) string {
+	// Map Go dtype to NumPy dtype; some tools write bool as <b1, so preserve
+	// whatever bool prefix was read
+	dtypeStr := arr.DType.Descriptor(order)
+	if dtypeStr == "" {
+		dtypeStr = Float64.Descriptor(order) // Default to float64
+	}
+	if arr.DType == Bool && len(arr.descr) == 3 && arr.descr[1:] == "b1" {
+		dtypeStr = arr.descr
+	}
+
+	// Format shape
+	shapeStr := "("
+	for i, dim := range arr.Shape {
+		if i > 0 {
+			shapeStr += ", "
+		}
+		shapeStr += strconv.Itoa(dim)
+	}
+	// Like Python tuples, one-element shapes need a trailing comma; 0-D
+	// shapes are written as ()
+	if len(arr.Shape) == 1 {
+		shapeStr += ","
+	}
+	shapeStr += ")"
+
+	// Create header string
+	fortranStr := "False"
+	if arr.Fortran {
+		fortranStr = "True"
+	}
+
+	values := map[string]string{
+		"descr":         "'" + dtypeStr + "'",
+		"fortran_order": fortranStr,
+		"shape":         shapeStr,
+	}
+	var b strings.Builder
+	b.WriteString("{")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "'%s': %s, ", key, values[key])
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// validateHeaderKeyOrder checks that keys lists each header key exactly once
+func validateHeaderKeyOrder(keys []string) error {
+	if len(keys) != len(defaultHeaderKeys) {
+		return fmt.Errorf("header key order must list %v, got %v", defaultHeaderKeys, keys)
+	}
+	for _, key := range defaultHeaderKeys {
+		if !slices.Contains(keys, key) {
+			return fmt.Errorf("header key order must list %v, got %v", defaultHeaderKeys, keys)
+		}
+	}
+	return nil
+}
+
+// Read reads a NumPy array from an io.Reader
+func Read[T any](r io.Reader) (*Array[T], error) {
+	return ReadWithOptions[T](r, ReadOptions{})
+}
+
+// ReadContiguous reads a NumPy array from an io.Reader, always returning data
+// in C order. Fortran-order files are transposed, so the result's Fortran flag
+// is never set. Use Read to keep the file's layout.
+func ReadContiguous[T any](r io.Reader) (*Array[T], error) {
+	return ReadWithOptions[T](r, ReadOptions{ForceCOrder: true})
+}
+
+// ReadWithOptions reads a NumPy array from an io.Reader using the given options
+func ReadWithOptions[T any](r io.Reader, opts ReadOptions) (*Array[T], error) {
+	// Read and parse header
+	hdr, _, err := readHeader(r)
+	if err != nil {
+		return nil, err
 	}
 
-	// Open the zip file
-	zipReader, err := zip.OpenReader(path)
+	return readBody[T](r, hdr, opts)
+}
+
+// ReadWithTrailing reads a NumPy array from an io.Reader and returns any bytes
+// that follow the array data, such as records appended after the array
+func ReadWithTrailing[T any](r io.Reader) (*Array[T], []byte, error) {
+	arr, err := Read[T](r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open NPZ file: %w", err)
+		return nil, nil, err
 	}
-	defer zipReader.Close()
-
-	// Create NPZ file
-	npz := NewNPZFile()
 
-	// Process each file in the zip
-	for _, f := range zipReader.File {
-		// Skip directories
-		if f.FileInfo().IsDir() {
-			continue
-		}
+	trailing, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read trailing data: %w", err)
+	}
+	return arr, trailing, nil
+}
 
-		// Extract name
-		name := f.Name
-		name = strings.TrimSuffix(name, ".npy")
+// ReadN reads exactly n consecutive arrays of the same type from r, as
+// written by successive calls to Write. Reaching the end of r before all n
+// arrays have been read is an error, as is a negative n.
+func ReadN[T any](r io.Reader, n int) ([]*Array[T], error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid array count %d", n)
+	}
 
-		// Open the file
-		rc, err := f.Open()
+	arrays := make([]*Array[T], 0, n)
+	for i := 0; i < n; i++ {
+		arr, err := Read[T](r)
 		if err != nil {
-			return nil, fmt.Errorf("failed to open file %s in NPZ: %w", f.Name, err)
+			return nil, fmt.Errorf("failed to read array %d of %d: %w", i+1, n, err)
 		}
+		arrays = append(arrays, arr)
+	}
+	return arrays, nil
+}
 
-		// We need to determine the type of the array before we can read it
-		// Since we can't know the type in advance, we'll read the header first to peek at the dtype
-		// This is a bit hacky, but we don't have a better option with Go's type system
-		// Read magic string and version
-		magic := make([]byte, 6)
-		if _, err := io.ReadFull(rc, magic); err != nil {
-			rc.Close()
-			return nil, fmt.Errorf("failed to read magic string from %s: %w", f.Name, err)
-		}
+// ReadAs reads a NumPy array from an io.Reader, reinterpreting its data bytes
+// as assumeDType instead of the declared dtype. The declared descriptor may be
+// one this package can't decode, such as '<V4' or '<c8', in which case its
+// item size is taken from the descriptor. The byte order of the declared
+// descriptor is kept. If the item sizes differ, the contiguous dimension is
+// rescaled like a NumPy view: the last one for C-order data and the first one
+// for Fortran-order data.
+func ReadAs[T any](r io.Reader, assumeDType DType) (*Array[T], error) {
+	var zero T
+	itemSize := assumeDType.ItemSize()
+	if itemSize == 0 {
+		return nil, fmt.Errorf("unsupported dtype: %s", assumeDType)
+	}
+	if size := int(unsafe.Sizeof(zero)); size != itemSize {
+		return nil, fmt.Errorf("type %T has size %d but dtype %s has item size %d", zero, size, assumeDType, itemSize)
+	}
 
-		// Read version
-		var major, minor uint8
-		if err := binary.Read(rc, binary.LittleEndian, &major); err != nil {
-			rc.Close()
-			return nil, fmt.Errorf("failed to read major version from %s: %w", f.Name, err)
-		}
-		if err := binary.Read(rc, binary.LittleEndian, &minor); err != nil {
-			rc.Close()
-			return nil, fmt.Errorf("failed to read minor version from %s: %w", f.Name, err)
-		}
+	// Read and parse header, accepting any declared dtype
+	hdr, _, err := readHeaderWith(r, true)
+	if err != nil {
+		return nil, err
+	}
 
-		// Read header length
-		var headerLen uint16
-		if major == 1 {
-			if err := binary.Read(rc, binary.LittleEndian, &headerLen); err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read header length from %s: %w", f.Name, err)
-			}
-		} else if major == 2 {
-			var headerLen32 uint32
-			if err := binary.Read(rc, binary.LittleEndian, &headerLen32); err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read header length from %s: %w", f.Name, err)
-			}
-			headerLen = uint16(headerLen32)
-		} else {
-			rc.Close()
-			return nil, fmt.Errorf("unsupported version in %s: %d.%d", f.Name, major, minor)
+	// Rescale the contiguous dimension, the last one in C order and the first
+	// in Fortran order, so the data size is unchanged
+	declared := hdr.DType.ItemSize()
+	if declared == 0 {
+		if declared, err = descriptorItemSize(hdr.Descr); err != nil {
+			return nil, err
 		}
-
-		// Read header
-		headerBytes := make([]byte, headerLen)
-		if _, err := io.ReadFull(rc, headerBytes); err != nil {
-			rc.Close()
-			return nil, fmt.Errorf("failed to read header from %s: %w", f.Name, err)
+	}
+	if declared != itemSize {
+		if len(hdr.Shape) == 0 {
+			return nil, fmt.Errorf("cannot reinterpret a 0-D %s array as %s", hdr.Descr, assumeDType)
 		}
-
-		// Parse header
-		hdr, err := parseHeader(string(headerBytes))
-		if err != nil {
-			rc.Close()
-			return nil, fmt.Errorf("failed to parse header from %s: %w", f.Name, err)
+		axis := len(hdr.Shape) - 1
+		if hdr.Fortran {
+			axis = 0
 		}
-
-		// Close the reader - we'll reopen the file to read the full array with proper typing
-		rc.Close()
-
-		// Reopen the file
-		rc, err = f.Open()
-		if err != nil {
-			return nil, fmt.Errorf("failed to reopen file %s in NPZ: %w", f.Name, err)
+		if hdr.Shape[axis] > math.MaxInt/declared {
+			return nil, fmt.Errorf("shape %v is too large", hdr.Shape)
 		}
-
-		// Read array based on dtype
-		var array interface{}
-		switch hdr.DType {
-		case Bool:
-			arr, err := Read[bool](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read bool array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Int8:
-			arr, err := Read[int8](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read int8 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Int16:
-			arr, err := Read[int16](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read int16 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Int32:
-			arr, err := Read[int32](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read int32 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Int64:
-			arr, err := Read[int64](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read int64 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Uint8:
-			arr, err := Read[uint8](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read uint8 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Uint16:
-			arr, err := Read[uint16](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read uint16 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Uint32:
-			arr, err := Read[uint32](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read uint32 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Uint64:
-			arr, err := Read[uint64](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read uint64 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Float32:
-			arr, err := Read[float32](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read float32 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		case Float64:
-			arr, err := Read[float64](rc)
-			if err != nil {
-				rc.Close()
-				return nil, fmt.Errorf("failed to read float64 array from %s: %w", f.Name, err)
-			}
-			array = arr
-		default:
-			rc.Close()
-			return nil, fmt.Errorf("unsupported dtype in %s: %s", f.Name, hdr.DType)
+		contiguous := hdr.Shape[axis] * declared
+		if contiguous%itemSize != 0 {
+			return nil, fmt.Errorf("contiguous dimension of %d bytes is not a multiple of the %s item size %d", contiguous, assumeDType, itemSize)
 		}
-
-		rc.Close()
-		npz.arrays[name] = array
+		hdr.Shape = append([]int(nil), hdr.Shape...)
+		hdr.Shape[axis] = contiguous / itemSize
 	}
+	hdr.DType = assumeDType
+	hdr.Descr = assumeDType.Descriptor(hdr.ByteOrder)
 
-	return npz, nil
+	return readBody[T](r, hdr, ReadOptions{})
 }
 
-// WriteNPZFile writes multiple NumPy arrays to a .npz file
-func WriteNPZFile(path string, npz *NPZFile) error {
-	// Ensure correct file extension
-	if !strings.HasSuffix(path, ".npz") {
-		path += ".npz" // Automatically add extension if missing
+// descriptorItemSize returns the item size in bytes given by the digits of a
+// descriptor such as '<c8' or '<M8[ns]'. Unicode strings use 4 bytes per
+// character.
+func descriptorItemSize(descr string) (int, error) {
+	match := regexp.MustCompile(`^[<>|=]?([A-Za-z])(\d+)`).FindStringSubmatch(descr)
+	if match == nil {
+		return 0, fmt.Errorf("cannot determine item size of descriptor %s", descr)
+	}
+	size, err := strconv.Atoi(match[2])
+	if err != nil || size <= 0 || size > math.MaxInt/4 {
+		return 0, fmt.Errorf("invalid item size in descriptor %s", descr)
 	}
+	if match[1] == "U" {
+		size *= 4
+	}
+	return size, nil
+}
 
-	// Create the zip file
-	zipFile, err := os.Create(path)
+// ReadRowsRange reads rows [start, end) of a 2D C-order NumPy array,
+// seeking past the preceding rows instead of reading them. The returned
+// array has Shape[0] set to end-start.
+func ReadRowsRange[T any](r io.ReadSeeker, start, end int) (*Array[T], error) {
+	// Read and parse header
+	hdr, _, err := readHeader(r)
 	if err != nil {
-		return fmt.Errorf("failed to create NPZ file: %w", err)
+		return nil, err
 	}
-	defer zipFile.Close()
-
-	// Create zip writer
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
-
-	// Write each array to the zip
-	for name, array := range npz.arrays {
-		// Ensure name has .npy extension
-		if !strings.HasSuffix(name, ".npy") {
-			name += ".npy"
-		}
 
-		// Create file in zip
-		w, err := zipWriter.Create(name)
-		if err != nil {
-			return fmt.Errorf("failed to create file %s in NPZ: %w", name, err)
-		}
-
-		// Write array based on type
-		switch arr := array.(type) {
-		case *Array[bool]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write bool array to %s: %w", name, err)
-			}
-		case *Array[int8]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write int8 array to %s: %w", name, err)
-			}
-		case *Array[int16]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write int16 array to %s: %w", name, err)
-			}
-		case *Array[int32]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write int32 array to %s: %w", name, err)
-			}
-		case *Array[int64]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write int64 array to %s: %w", name, err)
-			}
-		case *Array[uint8]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write uint8 array to %s: %w", name, err)
-			}
-		case *Array[uint16]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write uint16 array to %s: %w", name, err)
-			}
-		case *Array[uint32]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write uint32 array to %s: %w", name, err)
-			}
-		case *Array[uint64]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write uint64 array to %s: %w", name, err)
-			}
-		case *Array[float32]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write float32 array to %s: %w", name, err)
-			}
-		case *Array[float64]:
-			if err := Write(w, arr); err != nil {
-				return fmt.Errorf("failed to write float64 array to %s: %w", name, err)
-			}
-		default:
-			return fmt.Errorf("unsupported array type in %s", name)
-		}
+	// Validate layout and range
+	if len(hdr.Shape) != 2 {
+		return nil, fmt.Errorf("row range reads require a 2D array, got shape %v", hdr.Shape)
+	}
+	if hdr.Fortran {
+		return nil, fmt.Errorf("row range reads are not supported for Fortran-order arrays")
+	}
+	if start < 0 || end < start || end > hdr.Shape[0] {
+		return nil, fmt.Errorf("invalid row range [%d, %d) for %d rows", start, end, hdr.Shape[0])
 	}
 
-	return nil
-}
-
-// readData reads the actual data from the file based on the header information
-func readData[T any](r io.Reader, hdr *header) ([]T, error) {
-	// Calculate total number of elements
-	totalElements := 1
-	for _, dim := range hdr.Shape {
-		totalElements *= dim
+	// Skip to the first requested row
+	rowBytes := int64(hdr.Shape[1]) * int64(hdr.DType.ItemSize())
+	if _, err := r.Seek(int64(start)*rowBytes, io.SeekCurrent); err != nil {
+		return nil, fmt.Errorf("failed to seek to row %d: %w", start, err)
 	}
 
-	// Allocate slice for data
-	data := make([]T, totalElements)
+	// Read only the requested rows
+	rowsHdr := *hdr
+	rowsHdr.Shape = []int{end - start, hdr.Shape[1]}
+	return readBody[T](r, &rowsHdr, ReadOptions{})
+}
 
+// readBody reads the data section described by hdr into a typed array
+func readBody[T any](r io.Reader, hdr *header, opts ReadOptions) (*Array[T], error) {
 	// Read data
-	if err := binary.Read(r, binary.LittleEndian, &data); err != nil {
+	data, err := readData[T](r, hdr)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read data: %w", err)
 	}
 
-	return data, nil
+	// Convert column-major data to row-major if requested
+	fortran := hdr.Fortran
+	if opts.ForceCOrder && fortran {
+		data = toCOrder(data, hdr.Shape)
+		fortran = false
+	}
+
+	return &Array[T]{
+		Data:      data,
+		Shape:     hdr.Shape,
+		DType:     hdr.DType,
+		Fortran:   fortran,
+		Version:   hdr.Version,
+		ByteOrder: hdr.ByteOrder,
+		descr:     hdr.Descr,
+	}, nil
 }
 
-// generateHeader creates a header string for a NumPy array
-func generateHeader[T any](arr *Array[T]) string {
-	// Map Go dtype to NumPy dtype
-	var dtypeStr string
-	switch arr.DType {
+// readAny reads a NumPy array from r without knowing its type in advance,
+// returning a pointer to an Array of the matching Go type
+func readAny(r io.Reader, opts ReadOptions) (interface{}, error) {
+	hdr, _, err := readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return readBodyAny(r, hdr, opts)
+}
+
+// readBodyAny reads the data section described by hdr into an Array of the
+// Go type matching the header's dtype
+func readBodyAny(r io.Reader, hdr *header, opts ReadOptions) (interface{}, error) {
+	switch hdr.DType {
 	case Bool:
-		dtypeStr = "|b1"
+		return boxed(readBody[bool](r, hdr, opts))
 	case Int8:
-		dtypeStr = "|i1"
+		return boxed(readBody[int8](r, hdr, opts))
 	case Int16:
-		dtypeStr = "<i2"
+		return boxed(readBody[int16](r, hdr, opts))
 	case Int32:
-		dtypeStr = "<i4"
+		return boxed(readBody[int32](r, hdr, opts))
 	case Int64:
-		dtypeStr = "<i8"
+		return boxed(readBody[int64](r, hdr, opts))
 	case Uint8:
-		dtypeStr = "|u1"
+		return boxed(readBody[uint8](r, hdr, opts))
 	case Uint16:
-		dtypeStr = "<u2"
+		return boxed(readBody[uint16](r, hdr, opts))
 	case Uint32:
-		dtypeStr = "<u4"
+		return boxed(readBody[uint32](r, hdr, opts))
 	case Uint64:
-		dtypeStr = "<u8"
+		return boxed(readBody[uint64](r, hdr, opts))
 	case Float32:
-		dtypeStr = "<f4"
+		return boxed(readBody[float32](r, hdr, opts))
 	case Float64:
-		dtypeStr = "<f8"
+		return boxed(readBody[float64](r, hdr, opts))
 	default:
-		dtypeStr = "<f8" // Default to float64
+		return nil, fmt.Errorf("unsupported dtype: %s", hdr.DType)
 	}
+}
 
-	// Format shape
-	shapeStr := "("
-	for i, dim := range arr.Shape {
-		if i > 0 {
-			shapeStr += ", "
-		}
-		shapeStr += strconv.Itoa(dim)
+// boxed converts a typed array result into an untyped one, ensuring a failed
+// read yields a nil interface rather than a typed nil pointer
+func boxed[T any](arr *Array[T], err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
 	}
-	// Handle empty or single-dimensional arrays
-	if len(arr.Shape) == 0 {
-		shapeStr += ","
-	} else if len(arr.Shape) == 1 {
-		shapeStr += ","
+	return arr, nil
+}
+
+// ReadRawHeader reads the format version and the header dictionary text from
+// r without parsing it, which is useful for inspecting files the parser
+// rejects. Trailing padding is removed from the returned dictionary.
+func ReadRawHeader(r io.Reader) (version [2]uint8, dict string, err error) {
+	version, headerStr, _, err := readRawHeader(r)
+	if err != nil {
+		return version, "", err
 	}
-	shapeStr += ")"
+	return version, strings.TrimRight(headerStr, " \t\r\n"), nil
+}
 
-	// Create header string
-	fortranStr := "False"
-	if arr.Fortran {
-		fortranStr = "True"
+// readHeader reads the magic string, version and header from r, returning the
+// parsed header and the total number of bytes consumed
+func readHeader(r io.Reader) (*header, int, error) {
+	return readHeaderWith(r, false)
+}
+
+// readHeaderWith reads a header like readHeader, passing anyDType on to
+// parseHeaderWith
+func readHeaderWith(r io.Reader, anyDType bool) (*header, int, error) {
+	version, headerStr, n, err := readRawHeader(r)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return fmt.Sprintf("{'descr': '%s', 'fortran_order': %s, 'shape': %s, }", dtypeStr, fortranStr, shapeStr)
+	// Validate and parse header
+	if err := validateHeader(headerStr); err != nil {
+		return nil, 0, fmt.Errorf("invalid header: %w", err)
+	}
+	hdr, err := parseHeaderWith(headerStr, anyDType)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse header: %w", err)
+	}
+	hdr.Version = version
+
+	return hdr, n, nil
 }
 
-// Read reads a NumPy array from an io.Reader
-func Read[T any](r io.Reader) (*Array[T], error) {
+// readRawHeader reads the magic string, version and unparsed header text from
+// r, returning them along with the total number of bytes consumed
+func readRawHeader(r io.Reader) ([2]uint8, string, int, error) {
+	var version [2]uint8
+
 	// Read magic string and version
 	magic := make([]byte, 6)
 	if _, err := io.ReadFull(r, magic); err != nil {
-		return nil, fmt.Errorf("failed to read magic string: %w", err)
+		return version, "", 0, fmt.Errorf("failed to read magic string: %w", err)
 	}
 	if !bytes.Equal(magic, []byte("\x93NUMPY")) {
-		return nil, fmt.Errorf("invalid magic string: %q", magic)
+		return version, "", 0, fmt.Errorf("invalid magic string: %q", magic)
 	}
 
 	// Read version
 	var major, minor uint8
 	if err := binary.Read(r, binary.LittleEndian, &major); err != nil {
-		return nil, fmt.Errorf("failed to read major version: %w", err)
+		return version, "", 0, fmt.Errorf("failed to read major version: %w", err)
 	}
 	if err := binary.Read(r, binary.LittleEndian, &minor); err != nil {
-		return nil, fmt.Errorf("failed to read minor version: %w", err)
+		return version, "", 0, fmt.Errorf("failed to read minor version: %w", err)
 	}
+	version = [2]uint8{major, minor}
 
-	// Read header length
-	var headerLen uint16
+	// Read header length, checking it against the limit before converting so
+	// it can't wrap on 32-bit platforms
+	var headerLen uint64
+	preambleLen := 8
 	if major == 1 {
-		if err := binary.Read(r, binary.LittleEndian, &headerLen); err != nil {
-			return nil, fmt.Errorf("failed to read header length: %w", err)
+		var headerLen16 uint16
+		if err := binary.Read(r, binary.LittleEndian, &headerLen16); err != nil {
+			return version, "", 0, fmt.Errorf("failed to read header length: %w", err)
 		}
+		headerLen = uint64(headerLen16)
+		preambleLen += 2
 	} else if major == 2 {
 		var headerLen32 uint32
 		if err := binary.Read(r, binary.LittleEndian, &headerLen32); err != nil {
-			return nil, fmt.Errorf("failed to read header length: %w", err)
+			return version, "", 0, fmt.Errorf("failed to read header length: %w", err)
 		}
-		headerLen = uint16(headerLen32)
+		headerLen = uint64(headerLen32)
+		preambleLen += 4
 	} else {
-		return nil, fmt.Errorf("unsupported version: %d.%d", major, minor)
+		return version, "", 0, fmt.Errorf("unsupported version: %d.%d", major, minor)
+	}
+	if headerLen > uint64(MaxHeaderSize) {
+		return version, "", 0, fmt.Errorf("header length %d exceeds limit of %d bytes", headerLen, MaxHeaderSize)
 	}
 
 	// Read header
 	headerBytes := make([]byte, headerLen)
 	if _, err := io.ReadFull(r, headerBytes); err != nil {
-		return nil, fmt.Errorf("failed to read header: %w", err)
+		return version, "", 0, fmt.Errorf("failed to read header: %w", err)
 	}
 
-	// Parse header
-	hdr, err := parseHeader(string(headerBytes))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse header: %w", err)
+	return version, string(headerBytes), preambleLen + int(headerLen), nil
+}
+
+// dataSize returns the number of data bytes declared by the header, or an
+// error if the size cannot be represented
+func (h *header) dataSize() (int64, error) {
+	if _, err := shapeElements(h.Shape); err != nil {
+		return 0, err
+	}
+	size := int64(h.DType.ItemSize())
+	for _, dim := range h.Shape {
+		if dim != 0 && size > math.MaxInt64/int64(dim) {
+			return 0, fmt.Errorf("shape %v is too large", h.Shape)
+		}
+		size *= int64(dim)
 	}
+	return size, nil
+}
 
-	// Read data
-	data, err := readData[T](r, hdr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read data: %w", err)
+// shapeElements returns the number of elements in an array of the given
+// shape, or an error if a dimension is negative or the count overflows int
+func shapeElements(shape []int) (int, error) {
+	count := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			return 0, fmt.Errorf("negative dimension in shape %v", shape)
+		}
+		if dim != 0 && count > math.MaxInt/dim {
+			return 0, fmt.Errorf("shape %v is too large", shape)
+		}
+		count *= dim
 	}
+	return count, nil
+}
 
-	return &Array[T]{
-		Data:    data,
-		Shape:   hdr.Shape,
-		DType:   hdr.DType,
-		Fortran: hdr.Fortran,
-	}, nil
+// WriteOptions controls how arrays are encoded when writing
+type WriteOptions struct {
+	ByteOrder  binary.ByteOrder // Byte order of the data; defaults to the array's ByteOrder, then little-endian
+	InferShape bool             // Treat a nil Shape as 1D with len(Data) elements
+
+	// PadTo, if positive, appends zero bytes after the data so the total
+	// output size is a multiple of PadTo, for consumers that mmap whole pages.
+	// Read ignores the padding; ReadWithTrailing returns it as trailing bytes.
+	PadTo int
+
+	// HeaderKeyOrder, if set, is the order of the descr, fortran_order and
+	// shape keys in the header dictionary. It must list each exactly once.
+	// The default matches NumPy.
+	HeaderKeyOrder []string
+
+	// SkipExtensionCheck makes WriteFileWithOptions write to path exactly as
+	// given instead of adding a missing .npy extension
+	SkipExtensionCheck bool
 }
 
 // Write writes a NumPy array to an io.Writer
 func Write[T any](w io.Writer, arr *Array[T]) error {
+	return WriteWithOptions(w, arr, WriteOptions{})
+}
+
+// WriteWithOptions writes a NumPy array to an io.Writer using the given options
+func WriteWithOptions[T any](w io.Writer, arr *Array[T], opts WriteOptions) error {
+	order := opts.ByteOrder
+	if order == nil {
+		order = arr.ByteOrder
+	}
+	if order == nil {
+		order = binary.LittleEndian
+	}
+
 	// Validate array
 	if arr.Data == nil {
 		return fmt.Errorf("array data is nil")
 	}
 	if arr.Shape == nil {
-		return fmt.Errorf("array shape is nil")
+		if !opts.InferShape {
+			return fmt.Errorf("array shape is nil; set Shape to []int{len(Data)} for a 1D array or use WriteOptions.InferShape")
+		}
+		inferred := *arr
+		inferred.Shape = []int{len(arr.Data)}
+		arr = &inferred
 	}
 	if arr.DType == "" {
 		return fmt.Errorf("array dtype is empty")
 	}
+	if opts.PadTo < 0 {
+		return fmt.Errorf("invalid padding alignment: %d", opts.PadTo)
+	}
+	keys := defaultHeaderKeys
+	if opts.HeaderKeyOrder != nil {
+		if err := validateHeaderKeyOrder(opts.HeaderKeyOrder); err != nil {
+			return err
+		}
+		keys = opts.HeaderKeyOrder
+	}
 
 	// Calculate total number of elements from shape
-	totalElements := 1
-	for _, dim := range arr.Shape {
-		totalElements *= dim
+	totalElements, err := shapeElements(arr.Shape)
+	if err != nil {
+		return err
 	}
 
 	// Validate data length
@@ -554,25 +1449,16 @@ func Write[T any](w io.Writer, arr *Array[T]) error {
 		return fmt.Errorf("failed to write magic string: %w", err)
 	}
 
-	// Write version (using v1.0)
+	// Write version (using v1.x, where x is carried over from a 1.x file)
 	if err := binary.Write(w, binary.LittleEndian, uint8(1)); err != nil {
 		return fmt.Errorf("failed to write major version: %w", err)
 	}
-	if err := binary.Write(w, binary.LittleEndian, uint8(0)); err != nil {
+	if err := binary.Write(w, binary.LittleEndian, v1Minor(arr.Version)); err != nil {
 		return fmt.Errorf("failed to write minor version: %w", err)
 	}
 
 	// Generate header
-	headerStr := generateHeader(arr)
-
-	// Header needs to be padded to be a multiple of 16 bytes (including the 10 byte file header)
-	// for alignment purposes
-	paddingLen := 16 - ((10 + len(headerStr)) % 16)
-	if paddingLen < 1 {
-		paddingLen += 16 // Ensure at least one padding char
-	}
-
-	headerStr += strings.Repeat(" ", paddingLen-1) + "\n"
+	headerStr := padHeader(generateHeaderWithKeys(arr, order, keys))
 
 	// Write header length
 	if err := binary.Write(w, binary.LittleEndian, uint16(len(headerStr))); err != nil {
@@ -584,31 +1470,114 @@ func Write[T any](w io.Writer, arr *Array[T]) error {
 		return fmt.Errorf("failed to write header: %w", err)
 	}
 
-	// Write data
-	if err := binary.Write(w, binary.LittleEndian, arr.Data); err != nil {
+	// Write data, letting registered dtypes encode themselves
+	var zero T
+	itemSize := int(unsafe.Sizeof(zero))
+	if custom, ok := lookupDType(arr.DType); ok {
+		if err := custom.write(w, arr.Data); err != nil {
+			return fmt.Errorf("failed to write %s data: %w", arr.DType, err)
+		}
+		itemSize = custom.itemSize
+	} else if err := writeData(w, order, arr.Data); err != nil {
 		return fmt.Errorf("failed to write data: %w", err)
 	}
 
+	// Pad the output to the requested alignment
+	if opts.PadTo > 0 {
+		written := 10 + len(headerStr) + len(arr.Data)*itemSize
+		if rem := written % opts.PadTo; rem != 0 {
+			if _, err := w.Write(make([]byte, opts.PadTo-rem)); err != nil {
+				return fmt.Errorf("failed to write padding: %w", err)
+			}
+		}
+	}
+
 	return nil
 }
 
-// parseHeader parses a NumPy header string into a header struct
-func parseHeader(headerStr string) (*header, error) {
-	// Extract dictionary content from the header string
-	re := regexp.MustCompile(`{.*}`)
-	dictStr := re.FindString(headerStr)
-	if dictStr == "" {
-		return nil, fmt.Errorf("invalid header format")
+// v1Minor returns the minor version to write for an array read with the given
+// version, which is only kept when the file was also format 1
+func v1Minor(version [2]uint8) uint8 {
+	if version[0] != 1 {
+		return 0
 	}
+	return version[1]
+}
 
-	// Extract shape
-	shapeRe := regexp.MustCompile(`'shape':\s*\(([\d,\s]*)\)`)
-	shapeMatch := shapeRe.FindStringSubmatch(dictStr)
-	if len(shapeMatch) < 2 {
-		return nil, fmt.Errorf("shape not found in header")
+// padHeaderTo pads a header string with spaces and a newline to exactly n
+// bytes; the caller must ensure it fits
+func padHeaderTo(headerStr string, n int) string {
+	return headerStr + strings.Repeat(" ", n-len(headerStr)-1) + "\n"
+}
+
+// padHeader pads a header dictionary with spaces and a terminating newline so
+// that, with the 10 byte v1.0 preamble, it ends on a 16 byte boundary
+func padHeader(headerStr string) string {
+	// Header needs to be padded to be a multiple of 16 bytes (including the 10 byte file header)
+	// for alignment purposes
+	paddingLen := 16 - ((10 + len(headerStr)) % 16)
+	if paddingLen < 1 {
+		paddingLen += 16 // Ensure at least one padding char
+	}
+
+	return headerStr + strings.Repeat(" ", paddingLen-1) + "\n"
+}
+
+// ErrUnsupportedFeature is returned when a file uses a NumPy feature this
+// package cannot decode, such as object, structured or datetime dtypes
+var ErrUnsupportedFeature = errors.New("unsupported NumPy feature")
+
+// unsupportedDType describes why a descriptor cannot be decoded, wrapping
+// ErrUnsupportedFeature for recognized but unsupported kinds of data
+func unsupportedDType(dtypeStr string) error {
+	var feature string
+	switch dtypeStr[1] {
+	case 'O':
+		feature = "object arrays require pickle, which is not supported"
+	case 'V':
+		feature = "void and structured dtypes are not supported"
+	case 'S', 'a', 'U':
+		feature = "string dtypes are not supported"
+	case 'M', 'm':
+		feature = "datetime and timedelta dtypes are not supported"
+	case 'c':
+		feature = "complex dtypes are not supported"
+	default:
+		return fmt.Errorf("unsupported dtype: %s", dtypeStr)
+	}
+	return fmt.Errorf("%w: %s (descriptor %s)", ErrUnsupportedFeature, feature, dtypeStr)
+}
+
+// validateHeader checks that only padding follows the header dictionary.
+// NumPy terminates the header with a newline, but some writers omit it, so a
+// missing newline is tolerated.
+func validateHeader(headerStr string) error {
+	end := strings.LastIndex(headerStr, "}")
+	if end < 0 {
+		return fmt.Errorf("header dictionary is not terminated")
+	}
+	if trailer := headerStr[end+1:]; strings.TrimRight(trailer, " \t\r\n") != "" {
+		return fmt.Errorf("unexpected data after header dictionary: %q", trailer)
+	}
+	return nil
+}
+
+// parseDim parses a shape dimension, rejecting values larger than maxDim
+// instead of letting them wrap around on platforms with a small int
+func parseDim(part string, maxDim int64) (int, error) {
+	dim, err := strconv.ParseInt(part, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid shape dimension: %s", part)
+	}
+	if dim > maxDim {
+		return 0, fmt.Errorf("shape dimension %d exceeds the platform maximum of %d", dim, maxDim)
 	}
+	return int(dim), nil
+}
 
-	shapeStr := shapeMatch[1]
+// parseShape parses the contents of a shape tuple, such as "2, 3,". Dimensions
+// may be separated by any mix of spaces, tabs and newlines.
+func parseShape(shapeStr string) ([]int, error) {
 	shapeParts := strings.Split(shapeStr, ",")
 	shape := make([]int, 0, len(shapeParts))
 	for _, part := range shapeParts {
@@ -616,28 +1585,86 @@ func parseHeader(headerStr string) (*header, error) {
 		if part == "" {
 			continue
 		}
-		dim, err := strconv.Atoi(part)
+		dim, err := parseDim(part, math.MaxInt)
 		if err != nil {
-			return nil, fmt.Errorf("invalid shape dimension: %s", part)
+			return nil, err
 		}
 		shape = append(shape, dim)
 	}
+	return shape, nil
+}
+
+// parseHeader parses a NumPy header string into a header struct. Keys and
+// string values may use either single or double quotes.
+func parseHeader(headerStr string) (*header, error) {
+	return parseHeaderWith(headerStr, false)
+}
+
+// parseHeaderWith parses a header like parseHeader. If anyDType is set, a
+// descriptor this package can't decode leaves DType empty instead of failing,
+// so the shape and order can still be used.
+func parseHeaderWith(headerStr string, anyDType bool) (*header, error) {
+	// Extract dictionary content from the header string, which may span lines
+	re := regexp.MustCompile(`(?s){.*}`)
+	dictStr := re.FindString(headerStr)
+	if dictStr == "" {
+		return nil, fmt.Errorf("invalid header format")
+	}
+
+	// Extract shape
+	shapeRe := regexp.MustCompile(`['"]shape['"]:\s*\(([\d,\s]*)\)`)
+	shapeMatch := shapeRe.FindStringSubmatch(dictStr)
+	if len(shapeMatch) < 2 {
+		return nil, fmt.Errorf("shape not found in header")
+	}
 
-	// Extract dtype
-	dtypeRe := regexp.MustCompile(`'descr':\s*'([^']*)'`)
-	dtypeMatch := dtypeRe.FindStringSubmatch(dictStr)
-	if len(dtypeMatch) < 2 {
-		return nil, fmt.Errorf("dtype not found in header")
+	shape, err := parseShape(shapeMatch[1])
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract dtype. A subarray descriptor such as ('<f4', (2,)) gives each
+	// element its own shape, which is appended to the array shape.
+	var dtypeStr string
+	var subshape []int
+	subarrayRe := regexp.MustCompile(`['"]descr['"]:\s*\(\s*(?:'([^']*)'|"([^"]*)")\s*,\s*\(([\d,\s]*)\)\s*,?\s*\)`)
+	if subMatch := subarrayRe.FindStringSubmatch(dictStr); subMatch != nil {
+		dtypeStr = subMatch[1] + subMatch[2] // Only one quote style matches
+		subshape, err = parseShape(subMatch[3])
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		dtypeRe := regexp.MustCompile(`['"]descr['"]:\s*(?:'([^']*)'|"([^"]*)")`)
+		dtypeMatch := dtypeRe.FindStringSubmatch(dictStr)
+		if len(dtypeMatch) < 3 {
+			return nil, fmt.Errorf("dtype not found in header")
+		}
+		dtypeStr = dtypeMatch[1] + dtypeMatch[2] // Only one quote style matches
 	}
-	dtypeStr := dtypeMatch[1]
+
+	// Some writers put whitespace inside the descriptor, as in '< f8'
+	dtypeStr = strings.Join(strings.Fields(dtypeStr), "")
 
 	// Extract endianness and map to Go data type
 	var dtype DType
+	var order binary.ByteOrder = binary.LittleEndian
 	if len(dtypeStr) >= 2 {
 		typeChar := dtypeStr[1:]
 
 		// Endianness doesn't matter for our Go representation
-		// We'll use the native Go types and handle endianness during read/write
+		// We'll use the native Go types and handle endianness during read/write.
+		// Any prefix is accepted for single-byte types, where order is meaningless.
+		switch dtypeStr[0] {
+		case '<', '|':
+		case '>':
+			order = binary.BigEndian
+		case '=':
+			order = binary.NativeEndian
+		default:
+			return nil, fmt.Errorf("invalid byte order in dtype: %s", dtypeStr)
+		}
+
 		switch typeChar {
 		case "b1":
 			dtype = Bool
@@ -662,23 +1689,39 @@ func parseHeader(headerStr string) (*header, error) {
 		case "f8":
 			dtype = Float64
 		default:
-			return nil, fmt.Errorf("unsupported dtype: %s", dtypeStr)
+			if _, ok := lookupDType(DType(typeChar)); ok {
+				dtype = DType(typeChar)
+			} else if !anyDType {
+				return nil, unsupportedDType(dtypeStr)
+			}
 		}
 	} else {
 		return nil, fmt.Errorf("invalid dtype format: %s", dtypeStr)
 	}
 
-	// Extract fortran_order (column-major vs row-major)
-	fortranRe := regexp.MustCompile(`'fortran_order':\s*(True|False)`)
+	// Extract fortran_order (column-major vs row-major). Some writers use 0/1
+	// or lowercase booleans instead of Python's True/False.
+	fortranRe := regexp.MustCompile(`['"]fortran_order['"]:\s*((?i:true|false)|0|1)\b`)
 	fortranMatch := fortranRe.FindStringSubmatch(dictStr)
 	if len(fortranMatch) < 2 {
 		return nil, fmt.Errorf("fortran_order not found in header")
 	}
-	fortran := fortranMatch[1] == "True"
+	fortran := fortranMatch[1] == "1" || strings.EqualFold(fortranMatch[1], "true")
+
+	// Subarray elements are always stored in C order, so they can only be
+	// flattened into the shape of a C-order array
+	if len(subshape) > 0 {
+		if fortran {
+			return nil, fmt.Errorf("%w: subarray dtypes in Fortran-order arrays are not supported", ErrUnsupportedFeature)
+		}
+		shape = append(shape, subshape...)
+	}
 
 	return &header{
-		Shape:   shape,
-		DType:   dtype,
-		Fortran: fortran,
+		Shape:     shape,
+		DType:     dtype,
+		Fortran:   fortran,
+		ByteOrder: order,
+		Descr:     dtypeStr,
 	}, nil
 }
@@ -1,13 +1,22 @@
 package npy
 
 import (
+	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestWriteReadFloat64 tests writing and reading a float64 array
@@ -368,6 +377,49 @@ func TestInMemoryReadWrite(t *testing.T) {
 	}
 }
 
+// TestSkipExtensionCheck tests reading and writing files without a .npy extension
+func TestSkipExtensionCheck(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	arr := &Array[int64]{Data: []int64{1, 2, 3}, Shape: []int{3}, DType: Int64}
+
+	// Write to data.bin exactly as named
+	filePath := filepath.Join(tempDir, "data.bin")
+	if err := WriteFileWithOptions(filePath, arr, WriteOptions{SkipExtensionCheck: true}); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		t.Fatalf("Expected %s to exist: %v", filePath, err)
+	}
+
+	// The default still rejects the extension
+	if _, err := ReadFile[int64](filePath); err == nil {
+		t.Error("Expected error when reading file with invalid extension, got nil")
+	}
+
+	readArr, err := ReadFileWithOptions[int64](filePath, ReadOptions{SkipExtensionCheck: true})
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if !reflect.DeepEqual(readArr.Data, arr.Data) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, arr.Data)
+	}
+
+	// The magic string still guards against non-NumPy files
+	textPath := filepath.Join(tempDir, "notes.txt")
+	if err := os.WriteFile(textPath, []byte("not an array"), 0644); err != nil {
+		t.Fatalf("Failed to write text file: %v", err)
+	}
+	if _, err := ReadFileWithOptions[int64](textPath, ReadOptions{SkipExtensionCheck: true}); err == nil {
+		t.Error("Expected error when reading non-NumPy file, got nil")
+	}
+}
+
 // TestInvalidFile tests handling of invalid files
 func TestInvalidFile(t *testing.T) {
 	// Try to read a non-existent file
@@ -427,3 +479,1968 @@ func TestUnsupportedDType(t *testing.T) {
 		t.Error("Expected error when reading unsupported dtype, got nil")
 	}
 }
+
+// TestReadForceCOrder tests converting a Fortran-order file to C order on read
+func TestReadForceCOrder(t *testing.T) {
+	// Create test array - 2x3 matrix in Fortran (column-major) order
+	// representing the logical matrix [[1, 2, 3], [4, 5, 6]]
+	arr := &Array[int32]{
+		Data:    []int32{1, 4, 2, 5, 3, 6},
+		Shape:   []int{2, 3},
+		DType:   Int32,
+		Fortran: true,
+	}
+
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Write array to file
+	filePath := filepath.Join(tempDir, "test_force_c.npy")
+	if err := WriteFile(filePath, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+
+	// Read array from file, converting to C order
+	readArr, err := ReadFileWithOptions[int32](filePath, ReadOptions{ForceCOrder: true})
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+
+	// Verify data is in row-major order
+	expected := []int32{1, 2, 3, 4, 5, 6}
+	if !reflect.DeepEqual(readArr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, expected)
+	}
+
+	// Verify the Fortran flag was cleared
+	if readArr.Fortran {
+		t.Errorf("Expected Fortran flag to be cleared")
+	}
+
+	// Verify shape is unchanged
+	if !reflect.DeepEqual(readArr.Shape, arr.Shape) {
+		t.Errorf("Shape mismatch. Got %v, want %v", readArr.Shape, arr.Shape)
+	}
+}
+
+// TestReadContiguous tests that Fortran-order files are returned in C order
+func TestReadContiguous(t *testing.T) {
+	// Create test array - 2x2x3 in Fortran order representing values 0..11 in C order
+	arr := &Array[float32]{
+		Data:    []float32{0, 6, 3, 9, 1, 7, 4, 10, 2, 8, 5, 11},
+		Shape:   []int{2, 2, 3},
+		DType:   Float32,
+		Fortran: true,
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	encoded := buf.Bytes()
+
+	readArr, err := ReadContiguous[float32](bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	expected := []float32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	if !reflect.DeepEqual(readArr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, expected)
+	}
+	if readArr.Fortran {
+		t.Errorf("Expected Fortran flag to be cleared")
+	}
+	if !reflect.DeepEqual(readArr.Shape, arr.Shape) {
+		t.Errorf("Shape mismatch. Got %v, want %v", readArr.Shape, arr.Shape)
+	}
+
+	// Read keeps the file's layout
+	faithful, err := Read[float32](bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if !faithful.Fortran || !reflect.DeepEqual(faithful.Data, arr.Data) {
+		t.Errorf("Expected Read to keep Fortran layout. Got %v (fortran=%v)", faithful.Data, faithful.Fortran)
+	}
+}
+
+// TestNPZCompressionLevel tests writing NPZ files at different compression levels
+func TestNPZCompressionLevel(t *testing.T) {
+	// Create a large, compressible test array
+	data := make([]float64, 100000)
+	for i := range data {
+		data[i] = float64(i % 100)
+	}
+	arr := &Array[float64]{
+		Data:    data,
+		Shape:   []int{len(data)},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	npz := NewNPZFile()
+	Add(npz, "data", arr)
+
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	sizes := make(map[int]int64)
+	for _, level := range []int{flate.NoCompression, flate.BestSpeed, flate.BestCompression} {
+		// Write NPZ file at this level
+		filePath := filepath.Join(tempDir, fmt.Sprintf("test_level_%d.npz", level))
+		if err := WriteNPZFileWithOptions(filePath, npz, NPZWriteOptions{CompressionLevel: level}); err != nil {
+			t.Fatalf("Failed to write NPZ file at level %d: %v", level, err)
+		}
+
+		info, err := os.Stat(filePath)
+		if err != nil {
+			t.Fatalf("Failed to stat NPZ file: %v", err)
+		}
+		sizes[level] = info.Size()
+
+		// Verify the archive reads back correctly
+		readNPZ, err := ReadNPZFile(filePath)
+		if err != nil {
+			t.Fatalf("Failed to read NPZ file at level %d: %v", level, err)
+		}
+		readArr, ok := Get[float64](readNPZ, "data")
+		if !ok {
+			t.Fatalf("Failed to get data from NPZ file at level %d", level)
+		}
+		if !reflect.DeepEqual(readArr.Data, arr.Data) {
+			t.Errorf("Data mismatch at level %d", level)
+		}
+	}
+
+	// Verify compression levels produce the expected size ordering
+	if sizes[flate.BestCompression] > sizes[flate.BestSpeed] {
+		t.Errorf("BestCompression (%d bytes) larger than BestSpeed (%d bytes)", sizes[flate.BestCompression], sizes[flate.BestSpeed])
+	}
+	if sizes[flate.BestSpeed] >= sizes[flate.NoCompression] {
+		t.Errorf("BestSpeed (%d bytes) not smaller than NoCompression (%d bytes)", sizes[flate.BestSpeed], sizes[flate.NoCompression])
+	}
+
+	// Verify an invalid level is rejected
+	if err := WriteNPZFileWithOptions(filepath.Join(tempDir, "bad.npz"), npz, NPZWriteOptions{CompressionLevel: 42}); err == nil {
+		t.Error("Expected error for invalid compression level, got nil")
+	}
+}
+
+// TestParseLargeDimension tests that oversized shape dimensions are rejected
+func TestParseLargeDimension(t *testing.T) {
+	// A 3-billion-element dimension fits in 64 bits
+	dim, err := parseDim("3000000000", math.MaxInt64)
+	if err != nil {
+		t.Fatalf("Failed to parse dimension: %v", err)
+	}
+	if int64(dim) != 3000000000 {
+		t.Errorf("Dimension mismatch. Got %d, want %d", dim, int64(3000000000))
+	}
+
+	// Simulate a 32-bit platform where the dimension would overflow int
+	if _, err := parseDim("3000000000", math.MaxInt32); err == nil {
+		t.Error("Expected error for dimension exceeding int32, got nil")
+	}
+
+	// Values beyond 64 bits are always rejected
+	if _, err := parseDim("99999999999999999999", math.MaxInt64); err == nil {
+		t.Error("Expected error for dimension exceeding int64, got nil")
+	}
+
+	// Verify the header path surfaces the error
+	headerStr := "{'descr': '<f8', 'fortran_order': False, 'shape': (99999999999999999999,), }"
+	if _, err := parseHeader(headerStr); err == nil {
+		t.Error("Expected error when parsing oversized shape in header, got nil")
+	}
+
+	// Dimensions that fit but whose product overflows are rejected on read
+	dict := "{'descr': '<f8', 'fortran_order': False, 'shape': (4294967296, 4294967296), }"
+	if arr, err := Read[float64](bytes.NewReader(rawNPY(dict, nil))); err == nil {
+		t.Errorf("Expected error for overflowing shape, got array with %d elements", len(arr.Data))
+	}
+
+	// And on write, where the product would wrap to the data length
+	wrapped := &Array[float64]{Data: []float64{}, Shape: []int{math.MaxInt/2 + 1, 4}, DType: Float64}
+	if err := Write(io.Discard, wrapped); err == nil {
+		t.Error("Expected error writing overflowing shape, got nil")
+	}
+	negative := &Array[float64]{Data: []float64{1}, Shape: []int{-1, -1}, DType: Float64}
+	if err := Write(io.Discard, negative); err == nil {
+		t.Error("Expected error writing negative shape, got nil")
+	}
+}
+
+// rawNPY builds a version 1.0 .npy stream from a header dictionary and raw data bytes
+func rawNPY(dict string, data []byte) []byte {
+	var buf bytes.Buffer
+	buf.Write([]byte("\x93NUMPY")) // Magic string
+	buf.Write([]byte{1, 0})        // Version 1.0
+	headerLen := len(dict) + 1
+	if rem := (10 + headerLen) % 16; rem != 0 {
+		headerLen += 16 - rem
+	}
+	headerStr := dict + strings.Repeat(" ", headerLen-len(dict)-1) + "\n"
+	binary.Write(&buf, binary.LittleEndian, uint16(len(headerStr)))
+	buf.Write([]byte(headerStr))
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+// writeRawNPZ writes a .npz file whose entries hold the given raw bytes
+func writeRawNPZ(t *testing.T, path string, entries map[string][]byte) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create NPZ file: %v", err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, data := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create entry %s: %v", name, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatalf("Failed to write entry %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close NPZ file: %v", err)
+	}
+}
+
+// TestNPZEntryOverclaim tests that NPZ entries declaring more data than present are rejected
+func TestNPZEntryOverclaim(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name  string
+		shape string
+	}{
+		{"short", "(1000,)"},
+		{"huge", "(1000000000000,)"},
+		{"overflow", "(4611686018427387904, 4)"},
+	}
+
+	for _, tt := range tests {
+		// Header claims many float64 elements but only one is present
+		dict := "{'descr': '<f8', 'fortran_order': False, 'shape': " + tt.shape + ", }"
+		entry := rawNPY(dict, make([]byte, 8))
+
+		filePath := filepath.Join(tempDir, tt.name+".npz")
+		writeRawNPZ(t, filePath, map[string][]byte{"data.npy": entry})
+
+		if _, err := ReadNPZFile(filePath); err == nil {
+			t.Errorf("Expected error for %s entry, got nil", tt.name)
+		}
+	}
+}
+
+// TestNPZForgedEntrySize tests that an entry whose zip header overstates its
+// uncompressed size cannot make the reader allocate the claimed size
+func TestNPZForgedEntrySize(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Header claims 100M float64 elements but only one is present
+	entry := rawNPY("{'descr': '<f8', 'fortran_order': False, 'shape': (100000000,), }", make([]byte, 8))
+	var compressed bytes.Buffer
+	fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+	if err != nil {
+		t.Fatalf("Failed to create deflate writer: %v", err)
+	}
+	fw.Write(entry)
+	fw.Close()
+
+	// Write the entry raw so the zip header can claim 800 MB uncompressed
+	filePath := filepath.Join(tempDir, "forged.npz")
+	f, err := os.Create(filePath)
+	if err != nil {
+		t.Fatalf("Failed to create NPZ file: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "data.npy",
+		Method:             zip.Deflate,
+		CRC32:              crc32.ChecksumIEEE(entry),
+		CompressedSize64:   uint64(compressed.Len()),
+		UncompressedSize64: 800_000_200,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create entry: %v", err)
+	}
+	w.Write(compressed.Bytes())
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close NPZ file: %v", err)
+	}
+	f.Close()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err = ReadNPZFile(filePath)
+	runtime.ReadMemStats(&after)
+
+	if err == nil {
+		t.Error("Expected error for forged entry size, got nil")
+	}
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 64<<20 {
+		t.Errorf("Reading forged entry allocated %d bytes, want at most %d", alloc, 64<<20)
+	}
+}
+
+// TestOversizedHeaderLength tests rejecting headers longer than MaxHeaderSize
+func TestOversizedHeaderLength(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, length := range []uint32{0x60000000, 0xffffffff, uint32(MaxHeaderSize) + 1} {
+		// A bare v2.0 preamble declaring a huge header
+		preamble := binary.LittleEndian.AppendUint32([]byte("\x93NUMPY\x02\x00"), length)
+
+		if _, err := Read[float64](bytes.NewReader(preamble)); err == nil || !strings.Contains(err.Error(), "exceeds limit") {
+			t.Errorf("Expected header limit error for length %d, got %v", length, err)
+		}
+
+		filePath := filepath.Join(tempDir, fmt.Sprintf("header%d.npz", length))
+		writeRawNPZ(t, filePath, map[string][]byte{"data.npy": preamble})
+		if _, err := ReadNPZFile(filePath); err == nil || !strings.Contains(err.Error(), "exceeds limit") {
+			t.Errorf("Expected header limit error in NPZ for length %d, got %v", length, err)
+		}
+	}
+}
+
+// TestWriteReadBigEndian tests writing and reading a big-endian array
+func TestWriteReadBigEndian(t *testing.T) {
+	// Create test array
+	arr := &Array[int32]{
+		Data:    []int32{1, -2, 3, 70000},
+		Shape:   []int{2, 2},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	// Write array to buffer in big-endian order
+	var buf bytes.Buffer
+	if err := WriteWithOptions(&buf, arr, WriteOptions{ByteOrder: binary.BigEndian}); err != nil {
+		t.Fatalf("Failed to write array to buffer: %v", err)
+	}
+
+	// Verify the header declares big-endian data
+	if !bytes.Contains(buf.Bytes(), []byte("'descr': '>i4'")) {
+		t.Errorf("Expected big-endian descriptor in header, got %q", buf.Bytes())
+	}
+
+	// Verify the first element is stored big-endian
+	raw := buf.Bytes()
+	dataStart := len(raw) - 4*len(arr.Data)
+	if !bytes.Equal(raw[dataStart:dataStart+4], []byte{0, 0, 0, 1}) {
+		t.Errorf("Expected big-endian first element, got %v", raw[dataStart:dataStart+4])
+	}
+
+	// Read array back from buffer
+	readArr, err := Read[int32](bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to read array from buffer: %v", err)
+	}
+
+	// Verify data
+	if !reflect.DeepEqual(readArr.Data, arr.Data) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, arr.Data)
+	}
+}
+
+// TestReadBigEndianFloat32 tests decoding big-endian data to host order and re-writing it faithfully
+func TestReadBigEndianFloat32(t *testing.T) {
+	expected := []float32{1.5, -0.25, 3e10, 0}
+	data := make([]byte, 4*len(expected))
+	for i, val := range expected {
+		binary.BigEndian.PutUint32(data[i*4:], math.Float32bits(val))
+	}
+	raw := rawNPY("{'descr': '>f4', 'fortran_order': False, 'shape': (2, 2), }", data)
+
+	arr, err := Read[float32](bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+	if arr.ByteOrder != binary.BigEndian {
+		t.Errorf("ByteOrder mismatch. Got %v, want %v", arr.ByteOrder, binary.BigEndian)
+	}
+
+	// Writing keeps the original byte order
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), raw) {
+		t.Errorf("Expected byte-identical rewrite of big-endian file")
+	}
+
+	// An explicit option still overrides it
+	buf.Reset()
+	if err := WriteWithOptions(&buf, arr, WriteOptions{ByteOrder: binary.LittleEndian}); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("'descr': '<f4'")) {
+		t.Errorf("Expected little-endian descriptor in header, got %q", buf.Bytes())
+	}
+
+	// Fingerprints compare values, not the byte order they were read in
+	fresh := &Array[float32]{Data: expected, Shape: []int{2, 2}, DType: Float32}
+	if fresh.Fingerprint() != arr.Fingerprint() {
+		t.Errorf("Expected fingerprint to ignore the original byte order")
+	}
+}
+
+// TestWriteInferShape tests writing an array with a nil shape
+func TestWriteInferShape(t *testing.T) {
+	// Create test array without a shape
+	arr := &Array[int16]{
+		Data:  []int16{1, 2, 3, 4},
+		DType: Int16,
+	}
+
+	// Verify writing fails by default with a helpful message
+	var buf bytes.Buffer
+	err := Write(&buf, arr)
+	if err == nil {
+		t.Fatal("Expected error for nil shape, got nil")
+	}
+	if !strings.Contains(err.Error(), "InferShape") {
+		t.Errorf("Expected error to suggest InferShape, got %v", err)
+	}
+
+	// Write with shape inference enabled
+	buf.Reset()
+	if err := WriteWithOptions(&buf, arr, WriteOptions{InferShape: true}); err != nil {
+		t.Fatalf("Failed to write array with inferred shape: %v", err)
+	}
+
+	// Verify the caller's array is unchanged
+	if arr.Shape != nil {
+		t.Errorf("Expected caller's shape to remain nil, got %v", arr.Shape)
+	}
+
+	// Read array back and verify it is 1D
+	readArr, err := Read[int16](bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if !reflect.DeepEqual(readArr.Shape, []int{4}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", readArr.Shape, []int{4})
+	}
+	if !reflect.DeepEqual(readArr.Data, arr.Data) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, arr.Data)
+	}
+}
+
+// TestSingleByteDescriptorPrefixes tests that every byte order prefix is accepted for 1-byte types
+func TestSingleByteDescriptorPrefixes(t *testing.T) {
+	types := []struct {
+		code  string
+		dtype DType
+	}{
+		{"b1", Bool},
+		{"i1", Int8},
+		{"u1", Uint8},
+	}
+
+	for _, prefix := range []string{"<", ">", "|", "="} {
+		for _, tt := range types {
+			descr := prefix + tt.code
+			t.Run(descr, func(t *testing.T) {
+				// Build a stream with two elements of data
+				dict := "{'descr': '" + descr + "', 'fortran_order': False, 'shape': (2,), }"
+				raw := rawNPY(dict, []byte{1, 0})
+
+				// Verify the header maps to the expected dtype
+				hdr, _, err := readHeader(bytes.NewReader(raw))
+				if err != nil {
+					t.Fatalf("Failed to read header: %v", err)
+				}
+				if hdr.DType != tt.dtype {
+					t.Errorf("DType mismatch. Got %v, want %v", hdr.DType, tt.dtype)
+				}
+
+				// Verify the data decodes identically regardless of prefix
+				arr, err := readAny(bytes.NewReader(raw), ReadOptions{})
+				if err != nil {
+					t.Fatalf("Failed to read array: %v", err)
+				}
+				var got []int
+				switch a := arr.(type) {
+				case *Array[bool]:
+					for _, v := range a.Data {
+						if v {
+							got = append(got, 1)
+						} else {
+							got = append(got, 0)
+						}
+					}
+				case *Array[int8]:
+					for _, v := range a.Data {
+						got = append(got, int(v))
+					}
+				case *Array[uint8]:
+					for _, v := range a.Data {
+						got = append(got, int(v))
+					}
+				}
+				if !reflect.DeepEqual(got, []int{1, 0}) {
+					t.Errorf("Data mismatch. Got %v, want %v", got, []int{1, 0})
+				}
+			})
+		}
+	}
+
+	// Verify an unknown prefix is rejected
+	if _, err := parseHeader("{'descr': '!i1', 'fortran_order': False, 'shape': (2,), }"); err == nil {
+		t.Error("Expected error for invalid byte order prefix, got nil")
+	}
+}
+
+// TestReadRowsRange tests reading a subset of rows from a 2D array
+func TestReadRowsRange(t *testing.T) {
+	// Create test array - 10x3 matrix
+	data := make([]float64, 30)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	arr := &Array[float64]{
+		Data:    data,
+		Shape:   []int{10, 3},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Write array to file
+	filePath := filepath.Join(tempDir, "test_rows.npy")
+	if err := WriteFile(filePath, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	// Read rows 2:5
+	rows, err := ReadRowsRange[float64](f, 2, 5)
+	if err != nil {
+		t.Fatalf("Failed to read rows: %v", err)
+	}
+
+	// Verify against the full array's slice
+	if !reflect.DeepEqual(rows.Data, data[2*3:5*3]) {
+		t.Errorf("Data mismatch. Got %v, want %v", rows.Data, data[2*3:5*3])
+	}
+	if !reflect.DeepEqual(rows.Shape, []int{3, 3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", rows.Shape, []int{3, 3})
+	}
+
+	// Verify out-of-range requests are rejected
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Failed to rewind file: %v", err)
+	}
+	if _, err := ReadRowsRange[float64](f, 8, 11); err == nil {
+		t.Error("Expected error for out-of-range rows, got nil")
+	}
+}
+
+// TestParseDoubleQuotedHeader tests parsing a header dictionary using double quotes
+func TestParseDoubleQuotedHeader(t *testing.T) {
+	headerStr := `{"descr": "<f8", "fortran_order": True, "shape": (2, 3), }`
+
+	hdr, err := parseHeader(headerStr)
+	if err != nil {
+		t.Fatalf("Failed to parse header: %v", err)
+	}
+
+	// Verify parsed fields
+	if hdr.DType != Float64 {
+		t.Errorf("DType mismatch. Got %v, want %v", hdr.DType, Float64)
+	}
+	if !hdr.Fortran {
+		t.Errorf("Fortran order mismatch. Got %v, want %v", hdr.Fortran, true)
+	}
+	if !reflect.DeepEqual(hdr.Shape, []int{2, 3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", hdr.Shape, []int{2, 3})
+	}
+
+	// Verify mixed quote styles are accepted too
+	mixed := `{'descr': "<i4", "fortran_order": False, 'shape': (4,), }`
+	hdr, err = parseHeader(mixed)
+	if err != nil {
+		t.Fatalf("Failed to parse mixed-quote header: %v", err)
+	}
+	if hdr.DType != Int32 {
+		t.Errorf("DType mismatch. Got %v, want %v", hdr.DType, Int32)
+	}
+}
+
+// TestDescriptorWhitespace tests descriptors with whitespace inside the quotes
+func TestDescriptorWhitespace(t *testing.T) {
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data, math.Float64bits(1.5))
+	binary.LittleEndian.PutUint64(data[8:], math.Float64bits(-2))
+
+	arr, err := Read[float64](bytes.NewReader(rawNPY("{'descr': '< f8', 'fortran_order': False, 'shape': (2,), }", data)))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if arr.DType != Float64 {
+		t.Errorf("DType mismatch. Got %v, want %v", arr.DType, Float64)
+	}
+	if expected := []float64{1.5, -2}; !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+
+	// Surrounding whitespace and byte order are handled too
+	hdr, err := parseHeader("{'descr': ' > i4 ', 'fortran_order': False, 'shape': (1,), }")
+	if err != nil {
+		t.Fatalf("Failed to parse header: %v", err)
+	}
+	if hdr.DType != Int32 || hdr.ByteOrder != binary.BigEndian || hdr.Descr != ">i4" {
+		t.Errorf("Header mismatch. Got %v/%v/%q, want int32/BigEndian/\">i4\"", hdr.DType, hdr.ByteOrder, hdr.Descr)
+	}
+}
+
+// TestGenerateHeaderShape tests that shapes are formatted exactly as NumPy writes them
+func TestGenerateHeaderShape(t *testing.T) {
+	tests := []struct {
+		shape    []int
+		data     int
+		expected string
+	}{
+		{[]int{}, 1, "{'descr': '<f8', 'fortran_order': False, 'shape': (), }"},
+		{[]int{5}, 5, "{'descr': '<f8', 'fortran_order': False, 'shape': (5,), }"},
+		{[]int{2, 3}, 6, "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }"},
+		{[]int{2, 3, 4}, 24, "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3, 4), }"},
+	}
+
+	for _, tt := range tests {
+		arr := &Array[float64]{Data: make([]float64, tt.data), Shape: tt.shape, DType: Float64}
+		if got := generateHeader(arr, binary.LittleEndian); got != tt.expected {
+			t.Errorf("Header mismatch for shape %v. Got %s, want %s", tt.shape, got, tt.expected)
+		}
+
+		// Every form must read back to the same shape
+		var buf bytes.Buffer
+		if err := Write(&buf, arr); err != nil {
+			t.Fatalf("Failed to write array with shape %v: %v", tt.shape, err)
+		}
+		readArr, err := Read[float64](&buf)
+		if err != nil {
+			t.Fatalf("Failed to read array with shape %v: %v", tt.shape, err)
+		}
+		if len(readArr.Shape) != len(tt.shape) || len(readArr.Data) != tt.data {
+			t.Errorf("Shape mismatch. Got %v, want %v", readArr.Shape, tt.shape)
+		}
+	}
+}
+
+// TestHeaderWithoutNewline tests reading a header that lacks the terminating newline
+func TestHeaderWithoutNewline(t *testing.T) {
+	// Build a stream whose header is padded with spaces but has no newline
+	dict := "{'descr': '<i2', 'fortran_order': False, 'shape': (3,), }"
+	headerStr := dict + strings.Repeat(" ", 80-10-len(dict))
+
+	var buf bytes.Buffer
+	buf.Write([]byte("\x93NUMPY")) // Magic string
+	buf.Write([]byte{1, 0})        // Version 1.0
+	binary.Write(&buf, binary.LittleEndian, uint16(len(headerStr)))
+	buf.Write([]byte(headerStr))
+	binary.Write(&buf, binary.LittleEndian, []int16{7, 8, 9})
+
+	// Verify the array reads correctly
+	readArr, err := Read[int16](bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if !reflect.DeepEqual(readArr.Data, []int16{7, 8, 9}) {
+		t.Errorf("Data mismatch. Got %v, want %v", readArr.Data, []int16{7, 8, 9})
+	}
+
+	// Verify garbage after the dictionary is still rejected
+	if err := validateHeader(dict + " junk\n"); err == nil {
+		t.Error("Expected error for data after header dictionary, got nil")
+	}
+}
+
+// TestBoolDescriptorRoundTrip tests that a <b1 bool descriptor is written back unchanged
+func TestBoolDescriptorRoundTrip(t *testing.T) {
+	// Build a stream using the non-canonical <b1 descriptor
+	dict := "{'descr': '<b1', 'fortran_order': False, 'shape': (3,), }"
+	raw := rawNPY(dict, []byte{1, 0, 1})
+
+	arr, err := Read[bool](bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+
+	// Write it back and verify the descriptor is preserved
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("'descr': '<b1'")) {
+		t.Errorf("Expected <b1 descriptor to be preserved, got %q", buf.Bytes())
+	}
+
+	// Verify newly constructed bool arrays still use the canonical |b1
+	fresh := &Array[bool]{Data: []bool{true}, Shape: []int{1}, DType: Bool}
+	buf.Reset()
+	if err := Write(&buf, fresh); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("'descr': '|b1'")) {
+		t.Errorf("Expected |b1 descriptor for new array, got %q", buf.Bytes())
+	}
+}
+
+// TestReadBoolNonzeroBytes tests that any nonzero byte reads as true
+func TestReadBoolNonzeroBytes(t *testing.T) {
+	dict := "{'descr': '|b1', 'fortran_order': False, 'shape': (4,), }"
+	raw := rawNPY(dict, []byte{0x00, 0x01, 0xFF, 0x00})
+
+	arr, err := Read[bool](bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+
+	expected := []bool{false, true, true, false}
+	if !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+
+	// Verify truncated data is reported
+	truncated := rawNPY(dict, []byte{0x01, 0x00})
+	if _, err := Read[bool](bytes.NewReader(truncated)); err == nil {
+		t.Error("Expected error for truncated bool data, got nil")
+	}
+}
+
+// TestDTypeDescriptor tests the NumPy descriptor for every dtype in both byte orders
+func TestDTypeDescriptor(t *testing.T) {
+	tests := []struct {
+		dtype  DType
+		little string
+		big    string
+	}{
+		{Bool, "|b1", "|b1"},
+		{Int8, "|i1", "|i1"},
+		{Int16, "<i2", ">i2"},
+		{Int32, "<i4", ">i4"},
+		{Int64, "<i8", ">i8"},
+		{Uint8, "|u1", "|u1"},
+		{Uint16, "<u2", ">u2"},
+		{Uint32, "<u4", ">u4"},
+		{Uint64, "<u8", ">u8"},
+		{Float32, "<f4", ">f4"},
+		{Float64, "<f8", ">f8"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.dtype.Descriptor(binary.LittleEndian); got != tt.little {
+			t.Errorf("Little-endian descriptor for %s mismatch. Got %q, want %q", tt.dtype, got, tt.little)
+		}
+		if got := tt.dtype.Descriptor(binary.BigEndian); got != tt.big {
+			t.Errorf("Big-endian descriptor for %s mismatch. Got %q, want %q", tt.dtype, got, tt.big)
+		}
+
+		// Verify the descriptor parses back to the same dtype
+		hdr, err := parseHeader("{'descr': '" + tt.big + "', 'fortran_order': False, 'shape': (1,), }")
+		if err != nil {
+			t.Fatalf("Failed to parse descriptor %q: %v", tt.big, err)
+		}
+		if hdr.DType != tt.dtype {
+			t.Errorf("Parsed dtype mismatch for %q. Got %v, want %v", tt.big, hdr.DType, tt.dtype)
+		}
+	}
+
+	// Verify nil order defaults to little-endian and unknown dtypes are empty
+	if got := Float64.Descriptor(nil); got != "<f8" {
+		t.Errorf("Nil-order descriptor mismatch. Got %q, want %q", got, "<f8")
+	}
+	if got := DType("complex128").Descriptor(binary.LittleEndian); got != "" {
+		t.Errorf("Expected empty descriptor for unknown dtype, got %q", got)
+	}
+}
+
+// TestUnsupportedFeatureDescriptors tests that unsupported kinds of data are reported distinctly
+func TestUnsupportedFeatureDescriptors(t *testing.T) {
+	tests := []struct {
+		descr  string
+		detail string
+	}{
+		{"|V16", "void"},
+		{"|O", "pickle"},
+		{"<U8", "string"},
+		{"|S4", "string"},
+		{"<M8[ns]", "datetime"},
+		{"<c16", "complex"},
+	}
+
+	for _, tt := range tests {
+		dict := "{'descr': '" + tt.descr + "', 'fortran_order': False, 'shape': (1,), }"
+		_, err := Read[float64](bytes.NewReader(rawNPY(dict, make([]byte, 16))))
+		if err == nil {
+			t.Errorf("Expected error for %s descriptor, got nil", tt.descr)
+			continue
+		}
+		if !errors.Is(err, ErrUnsupportedFeature) {
+			t.Errorf("Expected ErrUnsupportedFeature for %s, got %v", tt.descr, err)
+		}
+		if !strings.Contains(err.Error(), tt.detail) {
+			t.Errorf("Expected error for %s to mention %q, got %v", tt.descr, tt.detail, err)
+		}
+	}
+
+	// Verify unknown codes are not reported as unsupported features
+	_, err := parseHeader("{'descr': '<x4', 'fortran_order': False, 'shape': (1,), }")
+	if err == nil || errors.Is(err, ErrUnsupportedFeature) {
+		t.Errorf("Expected plain unsupported dtype error, got %v", err)
+	}
+}
+
+// TestNewNPZFromDir tests bundling a directory of .npy files into an NPZ file
+func TestNewNPZFromDir(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Write three arrays of different types plus an unrelated file
+	floats := &Array[float64]{Data: []float64{1.5, 2.5}, Shape: []int{2}, DType: Float64}
+	ints := &Array[int32]{Data: []int32{1, 2, 3, 4}, Shape: []int{2, 2}, DType: Int32}
+	bools := &Array[bool]{Data: []bool{true, false, true}, Shape: []int{3}, DType: Bool}
+	if err := WriteFile(filepath.Join(tempDir, "floats.npy"), floats); err != nil {
+		t.Fatalf("Failed to write floats: %v", err)
+	}
+	if err := WriteFile(filepath.Join(tempDir, "ints.npy"), ints); err != nil {
+		t.Fatalf("Failed to write ints: %v", err)
+	}
+	if err := WriteFile(filepath.Join(tempDir, "bools.npy"), bools); err != nil {
+		t.Fatalf("Failed to write bools: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "notes.txt"), []byte("ignore me"), 0644); err != nil {
+		t.Fatalf("Failed to write notes: %v", err)
+	}
+
+	// Bundle the directory
+	npz, err := NewNPZFromDir(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to bundle directory: %v", err)
+	}
+	if len(Keys(npz)) != 3 {
+		t.Errorf("Expected 3 keys, got %v", Keys(npz))
+	}
+
+	// Write and read back the archive
+	npzPath := filepath.Join(tempDir, "bundle.npz")
+	if err := WriteNPZFile(npzPath, npz); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+	readNPZ, err := ReadNPZFile(npzPath)
+	if err != nil {
+		t.Fatalf("Failed to read NPZ file: %v", err)
+	}
+
+	// Verify each array
+	readFloats, ok := Get[float64](readNPZ, "floats")
+	if !ok || !reflect.DeepEqual(readFloats.Data, floats.Data) {
+		t.Errorf("Floats mismatch. Got %v", readFloats)
+	}
+	readInts, ok := Get[int32](readNPZ, "ints")
+	if !ok || !reflect.DeepEqual(readInts.Data, ints.Data) {
+		t.Errorf("Ints mismatch. Got %v", readInts)
+	}
+	readBools, ok := Get[bool](readNPZ, "bools")
+	if !ok || !reflect.DeepEqual(readBools.Data, bools.Data) {
+		t.Errorf("Bools mismatch. Got %v", readBools)
+	}
+}
+
+// TestExtractNPZToDir tests exploding an NPZ file into individual .npy files
+func TestExtractNPZToDir(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Write a two-array archive
+	weights := &Array[float32]{Data: []float32{0.5, -1, 2}, Shape: []int{3}, DType: Float32}
+	labels := &Array[int64]{Data: []int64{1, 0, 1, 1}, Shape: []int{2, 2}, DType: Int64}
+	npz := NewNPZFile()
+	Add(npz, "weights", weights)
+	Add(npz, "labels", labels)
+	npzPath := filepath.Join(tempDir, "model.npz")
+	if err := WriteNPZFile(npzPath, npz); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+
+	// Extract into a directory that doesn't exist yet
+	outDir := filepath.Join(tempDir, "out")
+	if err := ExtractNPZToDir(npzPath, outDir); err != nil {
+		t.Fatalf("Failed to extract NPZ file: %v", err)
+	}
+
+	// Read back each file
+	readWeights, err := ReadFile[float32](filepath.Join(outDir, "weights.npy"))
+	if err != nil {
+		t.Fatalf("Failed to read weights: %v", err)
+	}
+	if !reflect.DeepEqual(readWeights.Data, weights.Data) || !reflect.DeepEqual(readWeights.Shape, weights.Shape) {
+		t.Errorf("Weights mismatch. Got %v %v, want %v %v", readWeights.Data, readWeights.Shape, weights.Data, weights.Shape)
+	}
+	readLabels, err := ReadFile[int64](filepath.Join(outDir, "labels.npy"))
+	if err != nil {
+		t.Fatalf("Failed to read labels: %v", err)
+	}
+	if !reflect.DeepEqual(readLabels.Data, labels.Data) || !reflect.DeepEqual(readLabels.Shape, labels.Shape) {
+		t.Errorf("Labels mismatch. Got %v %v, want %v %v", readLabels.Data, readLabels.Shape, labels.Data, labels.Shape)
+	}
+
+	// Entries that would escape the output directory are rejected
+	var entry bytes.Buffer
+	if err := Write(&entry, weights); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	for i, name := range []string{"../escaped.npy", "a/../../escaped.npy", "/abs.npy"} {
+		evilPath := filepath.Join(tempDir, fmt.Sprintf("evil%d.npz", i))
+		writeRawNPZ(t, evilPath, map[string][]byte{name: entry.Bytes()})
+
+		evilDir := filepath.Join(tempDir, fmt.Sprintf("evil%d", i), "out")
+		if err := ExtractNPZToDir(evilPath, evilDir); err == nil {
+			t.Errorf("Expected error extracting %q, got nil", name)
+		}
+		if err := NPZToCsvDir(evilPath, evilDir); err == nil {
+			t.Errorf("Expected error exporting %q, got nil", name)
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, fmt.Sprintf("evil%d", i), "escaped.npy")); err == nil {
+			t.Errorf("Entry %q was written outside the output directory", name)
+		}
+	}
+}
+
+// TestWriteNPZDeterministic tests that writing the same NPZ file twice produces identical bytes
+func TestWriteNPZDeterministic(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Use enough entries that map iteration order would vary between writes
+	npz := NewNPZFile()
+	for i := 0; i < 10; i++ {
+		arr := &Array[int32]{Data: []int32{int32(i), int32(i * 2)}, Shape: []int{2}, DType: Int32}
+		Add(npz, fmt.Sprintf("arr%d", i), arr)
+	}
+
+	// Keys must come back sorted
+	keys := Keys(npz)
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] > keys[i] {
+			t.Fatalf("Keys not sorted: %v", keys)
+		}
+	}
+
+	// Write the archive twice
+	firstPath := filepath.Join(tempDir, "first.npz")
+	secondPath := filepath.Join(tempDir, "second.npz")
+	if err := WriteNPZFile(firstPath, npz); err != nil {
+		t.Fatalf("Failed to write first NPZ file: %v", err)
+	}
+	if err := WriteNPZFile(secondPath, npz); err != nil {
+		t.Fatalf("Failed to write second NPZ file: %v", err)
+	}
+
+	first, err := os.ReadFile(firstPath)
+	if err != nil {
+		t.Fatalf("Failed to read first NPZ file: %v", err)
+	}
+	second, err := os.ReadFile(secondPath)
+	if err != nil {
+		t.Fatalf("Failed to read second NPZ file: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Errorf("NPZ output differs between writes")
+	}
+}
+
+// TestShapeWhitespace tests parsing shapes with tabs, newlines and repeated spaces
+func TestShapeWhitespace(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape string
+	}{
+		{"tab", "(2,\t 3, )"},
+		{"spaces", "(2,    3)"},
+		{"newline", "(2,\n 3,\n)"},
+		{"leading", "( \t2 ,3 )"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := make([]byte, 6*8)
+			for i := 0; i < 6; i++ {
+				binary.LittleEndian.PutUint64(data[i*8:], math.Float64bits(float64(i)))
+			}
+			dict := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': %s, }", tt.shape)
+
+			arr, err := Read[float64](bytes.NewReader(rawNPY(dict, data)))
+			if err != nil {
+				t.Fatalf("Failed to read array: %v", err)
+			}
+			if !reflect.DeepEqual(arr.Shape, []int{2, 3}) {
+				t.Errorf("Shape mismatch. Got %v, want %v", arr.Shape, []int{2, 3})
+			}
+			if !reflect.DeepEqual(arr.Data, []float64{0, 1, 2, 3, 4, 5}) {
+				t.Errorf("Data mismatch. Got %v", arr.Data)
+			}
+		})
+	}
+}
+
+// TestReadRawHeader tests reading the unparsed header dictionary
+func TestReadRawHeader(t *testing.T) {
+	arr := &Array[int16]{Data: []int16{1, 2, 3, 4, 5, 6}, Shape: []int{2, 3}, DType: Int16}
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+
+	version, dict, err := ReadRawHeader(&buf)
+	if err != nil {
+		t.Fatalf("Failed to read raw header: %v", err)
+	}
+	if version != [2]uint8{1, 0} {
+		t.Errorf("Version mismatch. Got %v, want %v", version, [2]uint8{1, 0})
+	}
+	expected := "{'descr': '<i2', 'fortran_order': False, 'shape': (2, 3), }"
+	if dict != expected {
+		t.Errorf("Dict mismatch. Got %q, want %q", dict, expected)
+	}
+
+	// Headers the parser rejects can still be inspected
+	rejected := "{'descr': '|O', 'fortran_order': False, 'shape': (1,), }"
+	_, dict, err = ReadRawHeader(bytes.NewReader(rawNPY(rejected, nil)))
+	if err != nil {
+		t.Fatalf("Failed to read raw header: %v", err)
+	}
+	if dict != rejected {
+		t.Errorf("Dict mismatch. Got %q, want %q", dict, rejected)
+	}
+}
+
+// TestNPZMetadata tests round-tripping the description and creation time of an NPZ file
+func TestNPZMetadata(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create an NPZ file with metadata
+	npz := NewNPZFile()
+	Add(npz, "x", &Array[float64]{Data: []float64{1, 2}, Shape: []int{2}, DType: Float64})
+	npz.Description = "training split, seed 42"
+	npz.Created = time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	npzPath := filepath.Join(tempDir, "meta.npz")
+	if err := WriteNPZFile(npzPath, npz); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+
+	// Read it back
+	readNPZ, err := ReadNPZFile(npzPath)
+	if err != nil {
+		t.Fatalf("Failed to read NPZ file: %v", err)
+	}
+	if readNPZ.Description != npz.Description {
+		t.Errorf("Description mismatch. Got %q, want %q", readNPZ.Description, npz.Description)
+	}
+	if !readNPZ.Created.Equal(npz.Created) {
+		t.Errorf("Created mismatch. Got %v, want %v", readNPZ.Created, npz.Created)
+	}
+
+	// The metadata entry must not appear as an array
+	if keys := Keys(readNPZ); !reflect.DeepEqual(keys, []string{"x"}) {
+		t.Errorf("Keys mismatch. Got %v, want %v", keys, []string{"x"})
+	}
+
+	// Archives without metadata have no metadata entry
+	plain := NewNPZFile()
+	Add(plain, "x", &Array[float64]{Data: []float64{1, 2}, Shape: []int{2}, DType: Float64})
+	plainPath := filepath.Join(tempDir, "plain.npz")
+	if err := WriteNPZFile(plainPath, plain); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+	zr, err := zip.OpenReader(plainPath)
+	if err != nil {
+		t.Fatalf("Failed to open NPZ file: %v", err)
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if f.Name == "metadata.json" {
+			t.Errorf("Unexpected metadata entry in archive without metadata")
+		}
+	}
+}
+
+// TestAddNameCollision tests that names differing only by a .npy suffix collide
+func TestAddNameCollision(t *testing.T) {
+	arr1 := &Array[float64]{Data: []float64{1}, Shape: []int{1}, DType: Float64}
+	arr2 := &Array[float64]{Data: []float64{2}, Shape: []int{1}, DType: Float64}
+
+	// Add normalizes names, so the second array replaces the first
+	npz := NewNPZFile()
+	Add(npz, "x", arr1)
+	Add(npz, "x.npy", arr2)
+	if keys := Keys(npz); !reflect.DeepEqual(keys, []string{"x"}) {
+		t.Errorf("Keys mismatch. Got %v, want %v", keys, []string{"x"})
+	}
+	if got, ok := Get[float64](npz, "x"); !ok || got != arr2 {
+		t.Errorf("Expected x to hold the most recently added array")
+	}
+
+	// AddChecked reports the collision instead
+	checked := NewNPZFile()
+	if err := AddChecked(checked, "x", arr1); err != nil {
+		t.Fatalf("Failed to add x: %v", err)
+	}
+	if err := AddChecked(checked, "x.npy", arr2); err == nil {
+		t.Errorf("Expected error adding x.npy after x")
+	}
+	if err := AddChecked(checked, "y.npy", arr2); err != nil {
+		t.Errorf("Failed to add y.npy: %v", err)
+	}
+	if keys := Keys(checked); !reflect.DeepEqual(keys, []string{"x", "y"}) {
+		t.Errorf("Keys mismatch. Got %v, want %v", keys, []string{"x", "y"})
+	}
+	if got, ok := Get[float64](checked, "x"); !ok || got != arr1 {
+		t.Errorf("Expected x to keep the first array")
+	}
+}
+
+// TestGetAll tests collecting every array of one type from an NPZ file
+func TestGetAll(t *testing.T) {
+	npz := NewNPZFile()
+	Add(npz, "a", &Array[float32]{Data: []float32{1}, Shape: []int{1}, DType: Float32})
+	Add(npz, "b", &Array[int32]{Data: []int32{2}, Shape: []int{1}, DType: Int32})
+	Add(npz, "c", &Array[float32]{Data: []float32{3, 4}, Shape: []int{2}, DType: Float32})
+
+	floats := GetAllFloat32(npz)
+	if len(floats) != 2 {
+		t.Fatalf("Expected 2 float32 arrays, got %d", len(floats))
+	}
+	if !reflect.DeepEqual(floats["a"].Data, []float32{1}) || !reflect.DeepEqual(floats["c"].Data, []float32{3, 4}) {
+		t.Errorf("Data mismatch. Got %v and %v", floats["a"].Data, floats["c"].Data)
+	}
+	if _, ok := floats["b"]; ok {
+		t.Errorf("Unexpected int32 array in float32 results")
+	}
+
+	ints := GetAllInt32(npz)
+	if len(ints) != 1 || ints["b"] == nil {
+		t.Errorf("Expected only b in int32 results, got %v", ints)
+	}
+
+	if bools := GetAllBool(npz); len(bools) != 0 {
+		t.Errorf("Expected no bool arrays, got %d", len(bools))
+	}
+}
+
+// TestView tests getting every float64 array from an NPZ file as a typed map
+func TestView(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	npz := NewNPZFile()
+	Add(npz, "weights", &Array[float64]{Data: []float64{0.5, 1.5}, Shape: []int{2}, DType: Float64})
+	Add(npz, "bias", &Array[float64]{Data: []float64{-1}, Shape: []int{1}, DType: Float64})
+	Add(npz, "steps", &Array[int64]{Data: []int64{100}, Shape: []int{1}, DType: Int64})
+	path := filepath.Join(tempDir, "model.npz")
+	if err := WriteNPZFile(path, npz); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+
+	readNpz, err := ReadNPZFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read NPZ file: %v", err)
+	}
+	view := View[float64](readNpz)
+	if len(view) != 2 {
+		t.Fatalf("Expected 2 float64 arrays, got %d", len(view))
+	}
+	if !reflect.DeepEqual(view["weights"].Data, []float64{0.5, 1.5}) || !reflect.DeepEqual(view["bias"].Data, []float64{-1}) {
+		t.Errorf("Data mismatch. Got %v and %v", view["weights"].Data, view["bias"].Data)
+	}
+
+	// The view shares arrays with the archive
+	if got, _ := Get[float64](readNpz, "bias"); got != view["bias"] {
+		t.Errorf("Expected view to share arrays with the NPZ file")
+	}
+}
+
+// TestSubarrayDescriptor tests reading a subarray dtype as extra trailing dimensions
+func TestSubarrayDescriptor(t *testing.T) {
+	data := make([]byte, 8*4)
+	for i := 0; i < 8; i++ {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(float32(i)))
+	}
+
+	// Shape (4,) of 2-vectors flattens to (4, 2)
+	dict := "{'descr': ('<f4', (2,)), 'fortran_order': False, 'shape': (4,), }"
+	arr, err := Read[float32](bytes.NewReader(rawNPY(dict, data)))
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if !reflect.DeepEqual(arr.Shape, []int{4, 2}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", arr.Shape, []int{4, 2})
+	}
+	if arr.DType != Float32 {
+		t.Errorf("DType mismatch. Got %v, want %v", arr.DType, Float32)
+	}
+	expected := []float32{0, 1, 2, 3, 4, 5, 6, 7}
+	if !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+
+	// Fortran-order subarrays aren't supported
+	dict = "{'descr': ('<f4', (2,)), 'fortran_order': True, 'shape': (4,), }"
+	if _, err := Read[float32](bytes.NewReader(rawNPY(dict, data))); !errors.Is(err, ErrUnsupportedFeature) {
+		t.Errorf("Expected ErrUnsupportedFeature, got %v", err)
+	}
+}
+
+// TestNPZSuffixlessEntries tests that entries stored without .npy are keyed by base name
+func TestNPZSuffixlessEntries(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Encode an array and store it under a suffix-less and a suffixed name
+	var buf bytes.Buffer
+	arr := &Array[int16]{Data: []int16{4, 5, 6}, Shape: []int{3}, DType: Int16}
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	npzPath := filepath.Join(tempDir, "mixed.npz")
+	writeRawNPZ(t, npzPath, map[string][]byte{
+		"bare":         buf.Bytes(),
+		"suffixed.npy": buf.Bytes(),
+	})
+
+	npz, err := ReadNPZFile(npzPath)
+	if err != nil {
+		t.Fatalf("Failed to read NPZ file: %v", err)
+	}
+	if keys := Keys(npz); !reflect.DeepEqual(keys, []string{"bare", "suffixed"}) {
+		t.Errorf("Keys mismatch. Got %v, want %v", keys, []string{"bare", "suffixed"})
+	}
+
+	// Get works with or without the suffix for either entry
+	for _, name := range []string{"bare", "bare.npy", "suffixed", "suffixed.npy"} {
+		got, ok := Get[int16](npz, name)
+		if !ok {
+			t.Errorf("Get(%q) found nothing", name)
+			continue
+		}
+		if !reflect.DeepEqual(got.Data, arr.Data) {
+			t.Errorf("Get(%q) data mismatch. Got %v, want %v", name, got.Data, arr.Data)
+		}
+	}
+}
+
+// TestReadWithTrailing tests reading an array followed by extra bytes
+func TestReadWithTrailing(t *testing.T) {
+	arr := &Array[float32]{Data: []float32{1, 2, 3}, Shape: []int{3}, DType: Float32}
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	extra := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	buf.Write(extra)
+
+	got, trailing, err := ReadWithTrailing[float32](&buf)
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if !reflect.DeepEqual(got.Data, arr.Data) {
+		t.Errorf("Data mismatch. Got %v, want %v", got.Data, arr.Data)
+	}
+	if !bytes.Equal(trailing, extra) {
+		t.Errorf("Trailing mismatch. Got %v, want %v", trailing, extra)
+	}
+
+	// No trailing data yields an empty slice
+	buf.Reset()
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if _, trailing, err := ReadWithTrailing[float32](&buf); err != nil || len(trailing) != 0 {
+		t.Errorf("Expected no trailing data, got %v (err %v)", trailing, err)
+	}
+}
+
+// TestReadVersion tests that read arrays report the format version of the file
+func TestReadVersion(t *testing.T) {
+	arr := &Array[uint8]{Data: []uint8{1, 2}, Shape: []int{2}, DType: Uint8}
+	if arr.Version != [2]uint8{} {
+		t.Errorf("Expected zero version for a new array, got %v", arr.Version)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	got, err := Read[uint8](&buf)
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if got.Version != [2]uint8{1, 0} {
+		t.Errorf("Version mismatch. Got %v, want %v", got.Version, [2]uint8{1, 0})
+	}
+
+	// Version 2.0 files report their version too
+	dict := "{'descr': '|u1', 'fortran_order': False, 'shape': (2,), }"
+	raw := rawNPY(dict, []byte{1, 2})
+	v2 := append([]byte("\x93NUMPY\x02\x00"), binary.LittleEndian.AppendUint32(nil, uint32(len(raw)-10-2))...)
+	v2 = append(v2, raw[10:]...)
+	got, err = Read[uint8](bytes.NewReader(v2))
+	if err != nil {
+		t.Fatalf("Failed to read v2.0 array: %v", err)
+	}
+	if got.Version != [2]uint8{2, 0} {
+		t.Errorf("Version mismatch. Got %v, want %v", got.Version, [2]uint8{2, 0})
+	}
+}
+
+// TestMinorVersionRoundTrip tests that the minor version of a 1.x file survives a rewrite
+func TestMinorVersionRoundTrip(t *testing.T) {
+	dict := "{'descr': '|u1', 'fortran_order': False, 'shape': (2,), }"
+
+	for _, minor := range []uint8{0, 3} {
+		raw := rawNPY(dict, []byte{1, 2})
+		raw[7] = minor
+
+		arr, err := Read[uint8](bytes.NewReader(raw))
+		if err != nil {
+			t.Fatalf("Failed to read v1.%d array: %v", minor, err)
+		}
+
+		var buf bytes.Buffer
+		if err := Write(&buf, arr); err != nil {
+			t.Fatalf("Failed to write array: %v", err)
+		}
+		if got := buf.Bytes()[6:8]; !bytes.Equal(got, []byte{1, minor}) {
+			t.Errorf("Version mismatch. Got %v, want %v", got, []byte{1, minor})
+		}
+		if !bytes.Equal(buf.Bytes(), raw) {
+			t.Errorf("Expected byte-identical round trip for v1.%d", minor)
+		}
+	}
+
+	// Other major versions are written as 1.0
+	arr := &Array[uint8]{Data: []uint8{1, 2}, Shape: []int{2}, DType: Uint8, Version: [2]uint8{2, 1}}
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if got := buf.Bytes()[6:8]; !bytes.Equal(got, []byte{1, 0}) {
+		t.Errorf("Version mismatch. Got %v, want %v", got, []byte{1, 0})
+	}
+}
+
+// TestReadNPZFileFiltered tests decoding only the entries selected by a filter
+func TestReadNPZFileFiltered(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a mixed archive
+	npz := NewNPZFile()
+	Add(npz, "ids", &Array[int32]{Data: []int32{1, 2, 3}, Shape: []int{3}, DType: Int32})
+	Add(npz, "labels", &Array[int32]{Data: []int32{0, 1}, Shape: []int{2}, DType: Int32})
+	Add(npz, "features", &Array[float64]{Data: make([]float64, 100), Shape: []int{10, 10}, DType: Float64})
+	npzPath := filepath.Join(tempDir, "mixed.npz")
+	if err := WriteNPZFile(npzPath, npz); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+
+	// Load only the int32 entries, recording what the filter saw
+	seen := make(map[string][]int)
+	filtered, err := ReadNPZFileFiltered(npzPath, func(name string, dtype DType, shape []int) bool {
+		seen[name] = shape
+		return dtype == Int32
+	})
+	if err != nil {
+		t.Fatalf("Failed to read NPZ file: %v", err)
+	}
+
+	if keys := Keys(filtered); !reflect.DeepEqual(keys, []string{"ids", "labels"}) {
+		t.Errorf("Keys mismatch. Got %v, want %v", keys, []string{"ids", "labels"})
+	}
+	if ids, ok := Get[int32](filtered, "ids"); !ok || !reflect.DeepEqual(ids.Data, []int32{1, 2, 3}) {
+		t.Errorf("ids mismatch. Got %v", ids)
+	}
+	if !reflect.DeepEqual(seen["features"], []int{10, 10}) {
+		t.Errorf("Filter shape mismatch. Got %v, want %v", seen["features"], []int{10, 10})
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected filter to see 3 entries, got %d", len(seen))
+	}
+}
+
+// TestFortranOrderVariants tests fortran_order values written as integers or in other cases
+func TestFortranOrderVariants(t *testing.T) {
+	data := make([]byte, 4*2)
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint16(data[i*2:], uint16(i+1))
+	}
+
+	tests := []struct {
+		value   string
+		fortran bool
+	}{
+		{"0", false},
+		{"1", true},
+		{"false", false},
+		{"TRUE", true},
+		{"True", true},
+	}
+
+	for _, tt := range tests {
+		dict := fmt.Sprintf("{'descr': '<u2', 'fortran_order': %s, 'shape': (2, 2), }", tt.value)
+		arr, err := Read[uint16](bytes.NewReader(rawNPY(dict, data)))
+		if err != nil {
+			t.Errorf("Failed to read fortran_order %s: %v", tt.value, err)
+			continue
+		}
+		if arr.Fortran != tt.fortran {
+			t.Errorf("Fortran mismatch for %s. Got %v, want %v", tt.value, arr.Fortran, tt.fortran)
+		}
+	}
+
+	// Other values are still rejected
+	dict := "{'descr': '<u2', 'fortran_order': 10, 'shape': (2, 2), }"
+	if _, err := Read[uint16](bytes.NewReader(rawNPY(dict, data))); err == nil {
+		t.Error("Expected error for fortran_order 10, got nil")
+	}
+}
+
+// TestReadNPZStream tests buffering a streamed NPZ archive in memory and on disk
+func TestReadNPZStream(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Write a small archive
+	npz := NewNPZFile()
+	Add(npz, "x", &Array[int32]{Data: []int32{1, 2, 3}, Shape: []int{3}, DType: Int32})
+	npzPath := filepath.Join(tempDir, "small.npz")
+	if err := WriteNPZFile(npzPath, npz); err != nil {
+		t.Fatalf("Failed to write NPZ file: %v", err)
+	}
+	archive, err := os.ReadFile(npzPath)
+	if err != nil {
+		t.Fatalf("Failed to read NPZ file: %v", err)
+	}
+
+	checkArchive := func(name string, got *NPZFile) {
+		x, ok := Get[int32](got, "x")
+		if !ok || !reflect.DeepEqual(x.Data, []int32{1, 2, 3}) {
+			t.Errorf("%s: data mismatch. Got %v", name, x)
+		}
+	}
+
+	// In memory with a cap the archive fits under
+	got, err := ReadNPZStream(bytes.NewReader(archive), NPZBufferOptions{MaxMemory: int64(len(archive))})
+	if err != nil {
+		t.Fatalf("Failed to read in memory: %v", err)
+	}
+	checkArchive("memory", got)
+
+	// In memory with a cap the archive exceeds
+	if _, err := ReadNPZStream(bytes.NewReader(archive), NPZBufferOptions{MaxMemory: int64(len(archive)) - 1}); err == nil {
+		t.Error("Expected error when exceeding the in-memory limit, got nil")
+	}
+
+	// Through a temporary file in a custom directory, which is cleaned up
+	bufDir := filepath.Join(tempDir, "buffer")
+	if err := os.Mkdir(bufDir, 0755); err != nil {
+		t.Fatalf("Failed to create buffer dir: %v", err)
+	}
+	got, err = ReadNPZStream(bytes.NewReader(archive), NPZBufferOptions{TempFile: true, TempDir: bufDir})
+	if err != nil {
+		t.Fatalf("Failed to read through temp file: %v", err)
+	}
+	checkArchive("temp file", got)
+	if entries, err := os.ReadDir(bufDir); err != nil || len(entries) != 0 {
+		t.Errorf("Expected temp dir to be empty, got %v (err %v)", entries, err)
+	}
+}
+
+// TestReadAs tests reinterpreting the data bytes of a file as another dtype
+func TestReadAs(t *testing.T) {
+	// Write float32 bit patterns as a uint32 array
+	floats := []float32{1.5, -2, 0.25}
+	bits := make([]uint32, len(floats))
+	for i, f := range floats {
+		bits[i] = math.Float32bits(f)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, &Array[uint32]{Data: bits, Shape: []int{3}, DType: Uint32}); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	raw := buf.Bytes()
+
+	arr, err := ReadAs[float32](bytes.NewReader(raw), Float32)
+	if err != nil {
+		t.Fatalf("Failed to reinterpret array: %v", err)
+	}
+	if !reflect.DeepEqual(arr.Data, floats) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, floats)
+	}
+	if arr.DType != Float32 {
+		t.Errorf("DType mismatch. Got %v, want %v", arr.DType, Float32)
+	}
+
+	// Different item sizes rescale the last dimension
+	bytesArr, err := ReadAs[uint8](bytes.NewReader(raw), Uint8)
+	if err != nil {
+		t.Fatalf("Failed to reinterpret array as bytes: %v", err)
+	}
+	if !reflect.DeepEqual(bytesArr.Shape, []int{12}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", bytesArr.Shape, []int{12})
+	}
+	if _, err := ReadAs[float64](bytes.NewReader(raw), Float64); err == nil {
+		t.Error("Expected error for indivisible contiguous dimension, got nil")
+	}
+
+	// Fortran-order data rescales the first dimension, keeping each element's
+	// bytes together along the contiguous axis
+	fortran := rawNPY("{'descr': '<u2', 'fortran_order': True, 'shape': (2, 2), }", []byte{1, 0, 3, 0, 2, 0, 4, 0})
+	fortranBytes, err := ReadAs[uint8](bytes.NewReader(fortran), Uint8)
+	if err != nil {
+		t.Fatalf("Failed to reinterpret Fortran array: %v", err)
+	}
+	if !reflect.DeepEqual(fortranBytes.Shape, []int{4, 2}) || !fortranBytes.Fortran {
+		t.Errorf("Layout mismatch. Got shape %v, Fortran %v, want shape %v, Fortran true", fortranBytes.Shape, fortranBytes.Fortran, []int{4, 2})
+	}
+	if expected := []uint8{1, 2, 0, 0, 3, 4, 0, 0}; !reflect.DeepEqual(toCOrder(fortranBytes.Data, fortranBytes.Shape), expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", toCOrder(fortranBytes.Data, fortranBytes.Shape), expected)
+	}
+
+	// The Go type must match the assumed dtype's size
+	if _, err := ReadAs[float64](bytes.NewReader(raw), Float32); err == nil {
+		t.Error("Expected error for mismatched type size, got nil")
+	}
+
+	// Descriptors this package can't decode are reinterpreted by item size
+	data := make([]byte, 16)
+	for i, f := range []float32{1.5, -2, 0.25, 8} {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(f))
+	}
+	void := rawNPY("{'descr': '<V4', 'fortran_order': False, 'shape': (4,), }", data)
+	voidArr, err := ReadAs[float32](bytes.NewReader(void), Float32)
+	if err != nil {
+		t.Fatalf("Failed to reinterpret void array: %v", err)
+	}
+	if expected := []float32{1.5, -2, 0.25, 8}; !reflect.DeepEqual(voidArr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", voidArr.Data, expected)
+	}
+
+	// A complex64 pair of float32s becomes one uint64, then two floats
+	complexArr := rawNPY("{'descr': '<c8', 'fortran_order': False, 'shape': (2,), }", data)
+	words, err := ReadAs[uint64](bytes.NewReader(complexArr), Uint64)
+	if err != nil {
+		t.Fatalf("Failed to reinterpret complex array: %v", err)
+	}
+	if !reflect.DeepEqual(words.Shape, []int{2}) || words.Data[0] != binary.LittleEndian.Uint64(data) {
+		t.Errorf("Array mismatch. Got %v with shape %v", words.Data, words.Shape)
+	}
+	parts, err := ReadAs[float32](bytes.NewReader(complexArr), Float32)
+	if err != nil {
+		t.Fatalf("Failed to reinterpret complex array: %v", err)
+	}
+	if !reflect.DeepEqual(parts.Shape, []int{4}) || !reflect.DeepEqual(parts.Data, voidArr.Data) {
+		t.Errorf("Array mismatch. Got %v with shape %v", parts.Data, parts.Shape)
+	}
+
+	// Descriptors without an item size, such as objects, are still rejected
+	object := rawNPY("{'descr': '|O', 'fortran_order': False, 'shape': (2,), }", data)
+	if _, err := ReadAs[uint64](bytes.NewReader(object), Uint64); err == nil {
+		t.Error("Expected error for object descriptor, got nil")
+	}
+
+	// Plain reads still reject the unknown descriptors
+	if _, err := Read[float32](bytes.NewReader(void)); !errors.Is(err, ErrUnsupportedFeature) {
+		t.Errorf("Expected ErrUnsupportedFeature, got %v", err)
+	}
+}
+
+// TestWriteDataFastPath tests that bulk writes match binary.Write byte for byte
+func TestWriteDataFastPath(t *testing.T) {
+	// Create a large float64 array with awkward values
+	data := make([]float64, 100000)
+	for i := range data {
+		data[i] = float64(i)*1.1 - 5000
+	}
+	data[0], data[1], data[2] = math.NaN(), math.Inf(1), math.Copysign(0, -1)
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		var got, want bytes.Buffer
+		if err := writeData(&got, order, data); err != nil {
+			t.Fatalf("Failed to write data: %v", err)
+		}
+		if err := binary.Write(&want, order, data); err != nil {
+			t.Fatalf("Failed to encode data: %v", err)
+		}
+		if !bytes.Equal(got.Bytes(), want.Bytes()) {
+			t.Errorf("Output mismatch for %v", order)
+		}
+	}
+
+	// Bools must still encode as 0 and 1
+	var got bytes.Buffer
+	if err := writeData(&got, binary.LittleEndian, []bool{true, false, true}); err != nil {
+		t.Fatalf("Failed to write bools: %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), []byte{1, 0, 1}) {
+		t.Errorf("Bool output mismatch. Got %v", got.Bytes())
+	}
+
+	// And the array round-trips through Write and Read
+	arr := &Array[float64]{Data: data[3:], Shape: []int{len(data) - 3}, DType: Float64}
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	read, err := Read[float64](&buf)
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if !reflect.DeepEqual(read.Data, arr.Data) {
+		t.Errorf("Data mismatch after round trip")
+	}
+}
+
+// BenchmarkWrite measures writing a large float64 array
+func BenchmarkWrite(b *testing.B) {
+	arr := &Array[float64]{Data: make([]float64, 1<<20), Shape: []int{1 << 20}, DType: Float64}
+	for i := range arr.Data {
+		arr.Data[i] = float64(i)
+	}
+	b.SetBytes(int64(len(arr.Data) * 8))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := Write(io.Discard, arr); err != nil {
+			b.Fatalf("Failed to write array: %v", err)
+		}
+	}
+}
+
+// TestReadN tests reading a known number of arrays from a concatenated stream
+func TestReadN(t *testing.T) {
+	// Concatenate three arrays into one buffer
+	var buf bytes.Buffer
+	for i := 0; i < 3; i++ {
+		arr := &Array[int16]{Data: []int16{int16(i), int16(i * 10)}, Shape: []int{2}, DType: Int16}
+		if err := Write(&buf, arr); err != nil {
+			t.Fatalf("Failed to write array %d: %v", i, err)
+		}
+	}
+	raw := buf.Bytes()
+
+	arrays, err := ReadN[int16](bytes.NewReader(raw), 3)
+	if err != nil {
+		t.Fatalf("Failed to read arrays: %v", err)
+	}
+	if len(arrays) != 3 {
+		t.Fatalf("Expected 3 arrays, got %d", len(arrays))
+	}
+	for i, arr := range arrays {
+		if expected := []int16{int16(i), int16(i * 10)}; !reflect.DeepEqual(arr.Data, expected) {
+			t.Errorf("Array %d mismatch. Got %v, want %v", i, arr.Data, expected)
+		}
+	}
+
+	// Asking for more arrays than the stream holds fails
+	if _, err := ReadN[int16](bytes.NewReader(raw), 4); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected EOF error, got %v", err)
+	}
+
+	// A negative count is rejected
+	if _, err := ReadN[int16](bytes.NewReader(raw), -1); err == nil {
+		t.Error("Expected error for negative count, got nil")
+	}
+}
+
+// TestShortDataHints tests the targeted errors for data sections that end early
+func TestShortDataHints(t *testing.T) {
+	// Shape (3, 4) but only three float32 values, as if the last dimension were dropped
+	data := make([]byte, 3*4)
+	dict := "{'descr': '<f4', 'fortran_order': False, 'shape': (3, 4), }"
+	_, err := Read[float32](bytes.NewReader(rawNPY(dict, data)))
+	if err == nil {
+		t.Fatal("Expected error for short data, got nil")
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) || !strings.Contains(err.Error(), "without its last dimension") {
+		t.Errorf("Expected a shape hint, got %v", err)
+	}
+
+	// A float32 file read as float64
+	var buf bytes.Buffer
+	if err := Write(&buf, &Array[float32]{Data: []float32{1, 2, 3}, Shape: []int{3}, DType: Float32}); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	_, err = Read[float64](&buf)
+	if err == nil {
+		t.Fatal("Expected error for mismatched type, got nil")
+	}
+	if !strings.Contains(err.Error(), "check the element type") {
+		t.Errorf("Expected an element type hint, got %v", err)
+	}
+
+	// Other short reads keep the plain error
+	dict = "{'descr': '<f4', 'fortran_order': False, 'shape': (3, 4), }"
+	_, err = Read[float32](bytes.NewReader(rawNPY(dict, make([]byte, 5*4))))
+	if err == nil || strings.Contains(err.Error(), "check") {
+		t.Errorf("Expected a plain error, got %v", err)
+	}
+}
+
+// TestWritePadTo tests padding the output to a fixed alignment
+func TestWritePadTo(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	arr := &Array[float64]{Data: []float64{1, 2, 3, 4, 5}, Shape: []int{5}, DType: Float64}
+	path := filepath.Join(tempDir, "padded.npy")
+	if err := WriteFileWithOptions(path, arr, WriteOptions{PadTo: 4096}); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Size() != 4096 {
+		t.Errorf("Expected a 4096 byte file, got %d", info.Size())
+	}
+
+	// Readers ignore the padding
+	read, err := ReadFile[float64](path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if !reflect.DeepEqual(read.Data, arr.Data) {
+		t.Errorf("Data mismatch. Got %v, want %v", read.Data, arr.Data)
+	}
+
+	// Output that is already aligned gets no padding
+	var buf bytes.Buffer
+	aligned := &Array[float64]{Data: make([]float64, 2), Shape: []int{2}, DType: Float64}
+	if err := WriteWithOptions(&buf, aligned, WriteOptions{PadTo: 16}); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if buf.Len() != 80+16 {
+		t.Errorf("Expected 96 bytes, got %d", buf.Len())
+	}
+
+	if err := WriteWithOptions(&buf, arr, WriteOptions{PadTo: -1}); err == nil {
+		t.Error("Expected error for negative alignment, got nil")
+	}
+}
+
+// TestHeaderKeyOrder tests writing header keys in a custom order
+func TestHeaderKeyOrder(t *testing.T) {
+	arr := &Array[int32]{Data: []int32{1, 3, 2, 4}, Shape: []int{2, 2}, DType: Int32, Fortran: true}
+
+	var buf bytes.Buffer
+	opts := WriteOptions{HeaderKeyOrder: []string{"shape", "fortran_order", "descr"}}
+	if err := WriteWithOptions(&buf, arr, opts); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+
+	expected := "{'shape': (2, 2), 'fortran_order': True, 'descr': '<i4', }"
+	if !bytes.HasPrefix(buf.Bytes()[10:], []byte(expected)) {
+		t.Errorf("Header mismatch. Got %q, want prefix %q", buf.Bytes()[10:], expected)
+	}
+
+	readArr, err := Read[int32](&buf)
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if !reflect.DeepEqual(readArr.Data, arr.Data) || !reflect.DeepEqual(readArr.Shape, arr.Shape) || !readArr.Fortran {
+		t.Errorf("Array mismatch. Got %+v, want %+v", readArr, arr)
+	}
+
+	// Keys must be exactly descr, fortran_order and shape
+	for _, keys := range [][]string{
+		{},
+		{"descr", "shape"},
+		{"descr", "shape", "shape"},
+		{"descr", "fortran_order", "shape", "extra"},
+		{"descr", "fortran", "shape"},
+	} {
+		if err := WriteWithOptions(io.Discard, arr, WriteOptions{HeaderKeyOrder: keys}); err == nil {
+			t.Errorf("Expected error for key order %v, got nil", keys)
+		}
+	}
+}
+
+// TestEmptyNPZ tests writing, reading and exporting an archive with no arrays
+func TestEmptyNPZ(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Both a fresh and a zero-value NPZFile are writable
+	for i, npz := range []*NPZFile{NewNPZFile(), {}} {
+		path := filepath.Join(tempDir, fmt.Sprintf("empty%d.npz", i))
+		if err := WriteNPZFile(path, npz); err != nil {
+			t.Fatalf("Failed to write empty NPZ file: %v", err)
+		}
+
+		readNpz, err := ReadNPZFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read empty NPZ file: %v", err)
+		}
+		if keys := Keys(readNpz); len(keys) != 0 {
+			t.Errorf("Expected zero keys, got %v", keys)
+		}
+		if _, ok := Get[float64](readNpz, "missing"); ok {
+			t.Error("Expected Get to fail on empty archive")
+		}
+
+		// Export creates the output directory and nothing in it
+		outDir := filepath.Join(tempDir, fmt.Sprintf("csv%d", i))
+		if err := NPZToCsvDir(path, outDir); err != nil {
+			t.Fatalf("Failed to export empty NPZ file: %v", err)
+		}
+		entries, err := os.ReadDir(outDir)
+		if err != nil {
+			t.Fatalf("Failed to read output directory: %v", err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("Expected empty output directory, got %d entries", len(entries))
+		}
+	}
+}
+
+// TestReadNPZFileLenient tests recovering the valid entries of a partially corrupt archive
+func TestReadNPZFileLenient(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	var a, b bytes.Buffer
+	if err := Write(&a, &Array[float64]{Data: []float64{1, 2}, Shape: []int{2}, DType: Float64}); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if err := Write(&b, &Array[int32]{Data: []int32{3, 4, 5}, Shape: []int{3}, DType: Int32}); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+
+	// The corrupt entry's header declares more data than it holds
+	path := filepath.Join(tempDir, "partial.npz")
+	writeRawNPZ(t, path, map[string][]byte{
+		"a.npy":   a.Bytes(),
+		"b.npy":   b.Bytes(),
+		"bad.npy": rawNPY("{'descr': '<f8', 'fortran_order': False, 'shape': (100,), }", []byte{1, 2, 3}),
+	})
+
+	// The strict reader fails outright
+	if _, err := ReadNPZFile(path); err == nil {
+		t.Error("Expected error reading corrupt archive, got nil")
+	}
+
+	npz, errs, err := ReadNPZFileLenient(path)
+	if err != nil {
+		t.Fatalf("Failed to read NPZ file: %v", err)
+	}
+	if keys := Keys(npz); !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Errorf("Keys mismatch. Got %v, want %v", keys, []string{"a", "b"})
+	}
+	if arr, ok := Get[int32](npz, "b"); !ok || !reflect.DeepEqual(arr.Data, []int32{3, 4, 5}) {
+		t.Errorf("Expected b to be recovered, got %v", arr)
+	}
+	if len(errs) != 1 || errs["bad"] == nil {
+		t.Errorf("Expected a single error for bad, got %v", errs)
+	}
+
+	// An archive that cannot be opened is still an error
+	notZip := filepath.Join(tempDir, "broken.npz")
+	if err := os.WriteFile(notZip, []byte("not a zip"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, _, err := ReadNPZFileLenient(notZip); err == nil {
+		t.Error("Expected error for unreadable archive, got nil")
+	}
+}
@@ -0,0 +1,48 @@
+package npy
+
+// Integer is a constraint matching the Go integer types with a NumPy equivalent
+type Integer interface {
+	int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64
+}
+
+// Float is a constraint matching the Go floating point types with a NumPy equivalent
+type Float interface {
+	float32 | float64
+}
+
+// Numeric is a constraint matching all Go numeric types with a NumPy equivalent
+type Numeric interface {
+	Integer | Float
+}
+
+// dtypeOf returns the DType corresponding to the Go type T, or an empty
+// DType if T has no NumPy equivalent
+func dtypeOf[T any]() DType {
+	var zero T
+	switch any(zero).(type) {
+	case bool:
+		return Bool
+	case int8:
+		return Int8
+	case int16:
+		return Int16
+	case int32:
+		return Int32
+	case int64:
+		return Int64
+	case uint8:
+		return Uint8
+	case uint16:
+		return Uint16
+	case uint32:
+		return Uint32
+	case uint64:
+		return Uint64
+	case float32:
+		return Float32
+	case float64:
+		return Float64
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,257 @@
+package npy
+
+import (
+	"fmt"
+)
+
+// Where returns the flat indices of the elements satisfying the predicate
+func Where[T Numeric](a *Array[T], pred func(T) bool) []int {
+	indices := make([]int, 0)
+	for i, val := range a.Data {
+		if pred(val) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// Select returns a 1D array of the elements satisfying the predicate, in flat order
+func Select[T Numeric](a *Array[T], pred func(T) bool) *Array[T] {
+	data := make([]T, 0)
+	for _, val := range a.Data {
+		if pred(val) {
+			data = append(data, val)
+		}
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: []int{len(data)},
+		DType: a.DType,
+	}
+}
+
+// Clip returns a new array with each element clamped into [min, max],
+// preserving shape, order and dtype
+func Clip[T Numeric](a *Array[T], min, max T) *Array[T] {
+	data := make([]T, len(a.Data))
+	for i, val := range a.Data {
+		if val < min {
+			val = min
+		} else if val > max {
+			val = max
+		}
+		data[i] = val
+	}
+
+	return &Array[T]{
+		Data:    data,
+		Shape:   append([]int(nil), a.Shape...),
+		DType:   a.DType,
+		Fortran: a.Fortran,
+	}
+}
+
+// Tile repeats the array reps[i] times along each axis, like np.tile,
+// producing a new C-order array
+func (a *Array[T]) Tile(reps ...int) (*Array[T], error) {
+	// Validate repetitions
+	if len(reps) != len(a.Shape) {
+		return nil, fmt.Errorf("expected %d repetitions for array of rank %d, got %d", len(a.Shape), len(a.Shape), len(reps))
+	}
+	shape := make([]int, len(a.Shape))
+	total := 1
+	for i, rep := range reps {
+		if rep < 0 {
+			return nil, fmt.Errorf("invalid repetition count: %d", rep)
+		}
+		shape[i] = a.Shape[i] * rep
+		total *= shape[i]
+	}
+
+	// Walk the output coordinates in C order, wrapping into the source
+	data := make([]T, total)
+	coord := make([]int, len(shape))
+	srcCoord := make([]int, len(shape))
+	for i := range data {
+		for d, c := range coord {
+			srcCoord[d] = c % a.Shape[d]
+		}
+		data[i] = a.Data[ravelIndex(srcCoord, a.Shape, a.Fortran)]
+
+		for d := len(coord) - 1; d >= 0; d-- {
+			coord[d]++
+			if coord[d] < shape[d] {
+				break
+			}
+			coord[d] = 0
+		}
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: shape,
+		DType: a.DType,
+	}, nil
+}
+
+// Pad surrounds the array with before[i] and after[i] copies of value along
+// each axis, like np.pad in constant mode, producing a new C-order array
+func (a *Array[T]) Pad(before, after []int, value T) (*Array[T], error) {
+	// Validate padding
+	if len(before) != len(a.Shape) || len(after) != len(a.Shape) {
+		return nil, fmt.Errorf("expected %d padding widths for array of rank %d, got %d before and %d after", len(a.Shape), len(a.Shape), len(before), len(after))
+	}
+	shape := make([]int, len(a.Shape))
+	total := 1
+	for i := range shape {
+		if before[i] < 0 || after[i] < 0 {
+			return nil, fmt.Errorf("invalid padding width for axis %d: %d before, %d after", i, before[i], after[i])
+		}
+		shape[i] = before[i] + a.Shape[i] + after[i]
+		total *= shape[i]
+	}
+
+	data := make([]T, total)
+	for i := range data {
+		data[i] = value
+	}
+
+	// Walk the source coordinates, copying each element to its offset position
+	srcTotal := 1
+	for _, dim := range a.Shape {
+		srcTotal *= dim
+	}
+	coord := make([]int, len(a.Shape))
+	dstCoord := make([]int, len(a.Shape))
+	for n := 0; n < srcTotal; n++ {
+		for d, c := range coord {
+			dstCoord[d] = c + before[d]
+		}
+		data[ravelIndex(dstCoord, shape, false)] = a.Data[ravelIndex(coord, a.Shape, a.Fortran)]
+
+		for d := len(coord) - 1; d >= 0; d-- {
+			coord[d]++
+			if coord[d] < a.Shape[d] {
+				break
+			}
+			coord[d] = 0
+		}
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: shape,
+		DType: a.DType,
+	}, nil
+}
+
+// Roll cyclically shifts elements by shift positions along axis, like
+// np.roll, producing a new C-order array. Negative shifts move elements
+// toward the start, and a negative axis counts from the last axis.
+func (a *Array[T]) Roll(shift int, axis int) (*Array[T], error) {
+	// Validate axis
+	if axis < 0 {
+		axis += len(a.Shape)
+	}
+	if axis < 0 || axis >= len(a.Shape) {
+		return nil, fmt.Errorf("axis %d out of range for array of rank %d", axis, len(a.Shape))
+	}
+
+	total := 1
+	for _, dim := range a.Shape {
+		total *= dim
+	}
+
+	// Walk the output coordinates in C order, gathering from the shifted source
+	data := make([]T, total)
+	coord := make([]int, len(a.Shape))
+	srcCoord := make([]int, len(a.Shape))
+	n := a.Shape[axis]
+	for i := range data {
+		copy(srcCoord, coord)
+		srcCoord[axis] = ((coord[axis]-shift)%n + n) % n
+		data[i] = a.Data[ravelIndex(srcCoord, a.Shape, a.Fortran)]
+
+		for d := len(coord) - 1; d >= 0; d-- {
+			coord[d]++
+			if coord[d] < a.Shape[d] {
+				break
+			}
+			coord[d] = 0
+		}
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: append([]int(nil), a.Shape...),
+		DType: a.DType,
+	}, nil
+}
+
+// Resize returns a new C-order array with the given shape, like np.resize.
+// Elements are taken in C order and repeated cyclically when the new array is
+// larger, or truncated when it is smaller; an empty array is zero-filled. It
+// panics if any dimension is negative.
+func (a *Array[T]) Resize(shape ...int) *Array[T] {
+	total := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			panic(fmt.Sprintf("npy: negative dimension in resize shape %v", shape))
+		}
+		total *= dim
+	}
+
+	src := a.Data
+	if a.Fortran {
+		src = toCOrder(a.Data, a.Shape)
+	}
+
+	data := make([]T, total)
+	if len(src) > 0 {
+		for i := range data {
+			data[i] = src[i%len(src)]
+		}
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: append([]int(nil), shape...),
+		DType: a.DType,
+	}
+}
+
+// Apply replaces each element of the array with fn applied to it, in place
+func (a *Array[T]) Apply(fn func(T) T) {
+	for i, val := range a.Data {
+		a.Data[i] = fn(val)
+	}
+}
+
+// FromColumns builds a 2D C-order array whose columns are the given slices,
+// like np.column_stack. Every column must have the same length.
+func FromColumns[T Numeric](cols ...[]T) (*Array[T], error) {
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+
+	rows := len(cols[0])
+	for j, col := range cols {
+		if len(col) != rows {
+			return nil, fmt.Errorf("column %d has %d elements, want %d", j, len(col), rows)
+		}
+	}
+
+	data := make([]T, rows*len(cols))
+	for j, col := range cols {
+		for i, val := range col {
+			data[i*len(cols)+j] = val
+		}
+	}
+
+	return &Array[T]{
+		Data:  data,
+		Shape: []int{rows, len(cols)},
+		DType: dtypeOf[T](),
+	}, nil
+}
@@ -0,0 +1,301 @@
+package npy
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestWhereSelect tests selecting positive values from a mixed-sign array
+func TestWhereSelect(t *testing.T) {
+	// Create test array - 2x3 matrix with mixed signs
+	arr := &Array[int32]{
+		Data:    []int32{-1, 2, 0, 4, -5, 6},
+		Shape:   []int{2, 3},
+		DType:   Int32,
+		Fortran: false,
+	}
+	positive := func(v int32) bool { return v > 0 }
+
+	// Verify indices
+	indices := Where(arr, positive)
+	expectedIndices := []int{1, 3, 5}
+	if !reflect.DeepEqual(indices, expectedIndices) {
+		t.Errorf("Indices mismatch. Got %v, want %v", indices, expectedIndices)
+	}
+
+	// Verify selected values
+	selected := Select(arr, positive)
+	expectedData := []int32{2, 4, 6}
+	if !reflect.DeepEqual(selected.Data, expectedData) {
+		t.Errorf("Data mismatch. Got %v, want %v", selected.Data, expectedData)
+	}
+
+	// Verify shape and dtype
+	if !reflect.DeepEqual(selected.Shape, []int{3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", selected.Shape, []int{3})
+	}
+	if selected.DType != Int32 {
+		t.Errorf("DType mismatch. Got %v, want %v", selected.DType, Int32)
+	}
+
+	// Verify no matches produces an empty array
+	none := Select(arr, func(v int32) bool { return v > 100 })
+	if len(none.Data) != 0 || !reflect.DeepEqual(none.Shape, []int{0}) {
+		t.Errorf("Expected empty result, got data %v shape %v", none.Data, none.Shape)
+	}
+}
+
+// TestClip tests clamping a float array into [0, 1]
+func TestClip(t *testing.T) {
+	// Create test array with values outside the range on both ends
+	arr := &Array[float64]{
+		Data:    []float64{-0.5, 0.0, 0.25, 1.0, 1.5, 0.75},
+		Shape:   []int{3, 2},
+		DType:   Float64,
+		Fortran: true,
+	}
+
+	clipped := Clip(arr, 0.0, 1.0)
+
+	// Verify data
+	expected := []float64{0.0, 0.0, 0.25, 1.0, 1.0, 0.75}
+	if !reflect.DeepEqual(clipped.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", clipped.Data, expected)
+	}
+
+	// Verify shape, dtype and order are preserved
+	if !reflect.DeepEqual(clipped.Shape, arr.Shape) {
+		t.Errorf("Shape mismatch. Got %v, want %v", clipped.Shape, arr.Shape)
+	}
+	if clipped.DType != arr.DType {
+		t.Errorf("DType mismatch. Got %v, want %v", clipped.DType, arr.DType)
+	}
+	if clipped.Fortran != arr.Fortran {
+		t.Errorf("Fortran order mismatch. Got %v, want %v", clipped.Fortran, arr.Fortran)
+	}
+
+	// Verify the original array is unchanged
+	if arr.Data[0] != -0.5 || arr.Data[4] != 1.5 {
+		t.Errorf("Original array was modified: %v", arr.Data)
+	}
+}
+
+// TestTile tests tiling a 1x2 array into a 2x6 array
+func TestTile(t *testing.T) {
+	// Create test array
+	arr := &Array[int32]{
+		Data:    []int32{1, 2},
+		Shape:   []int{1, 2},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	tiled, err := arr.Tile(2, 3)
+	if err != nil {
+		t.Fatalf("Failed to tile array: %v", err)
+	}
+
+	// Verify shape
+	if !reflect.DeepEqual(tiled.Shape, []int{2, 6}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", tiled.Shape, []int{2, 6})
+	}
+
+	// Verify data
+	expected := []int32{1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2}
+	if !reflect.DeepEqual(tiled.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", tiled.Data, expected)
+	}
+
+	// Verify the number of repetitions must match the rank
+	if _, err := arr.Tile(2); err == nil {
+		t.Error("Expected error for mismatched repetitions, got nil")
+	}
+}
+
+// TestApply tests negating every element of an array in place
+func TestApply(t *testing.T) {
+	// Create test array
+	data := []float64{1.5, -2.0, 0.0, 4.25}
+	arr := &Array[float64]{
+		Data:    data,
+		Shape:   []int{2, 2},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	arr.Apply(func(v float64) float64 { return -v })
+
+	// Verify data
+	expected := []float64{-1.5, 2.0, 0.0, -4.25}
+	if !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+
+	// Verify the backing slice was modified rather than replaced
+	if &arr.Data[0] != &data[0] {
+		t.Errorf("Expected Apply to reuse the existing data slice")
+	}
+}
+
+// TestResize tests enlarging and shrinking an array
+func TestResize(t *testing.T) {
+	// Create test array
+	arr := &Array[int32]{
+		Data:    []int32{1, 2, 3},
+		Shape:   []int{3},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	// Enlarging repeats the data
+	larger := arr.Resize(5)
+	if !reflect.DeepEqual(larger.Shape, []int{5}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", larger.Shape, []int{5})
+	}
+	if expected := []int32{1, 2, 3, 1, 2}; !reflect.DeepEqual(larger.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", larger.Data, expected)
+	}
+
+	// Shrinking truncates it
+	smaller := arr.Resize(2)
+	if expected := []int32{1, 2}; !reflect.DeepEqual(smaller.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", smaller.Data, expected)
+	}
+
+	// Fortran-order data is read in C order
+	fortran := &Array[int32]{Data: []int32{1, 3, 2, 4}, Shape: []int{2, 2}, DType: Int32, Fortran: true}
+	reshaped := fortran.Resize(2, 3)
+	if expected := []int32{1, 2, 3, 4, 1, 2}; !reflect.DeepEqual(reshaped.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", reshaped.Data, expected)
+	}
+
+	// Empty arrays are zero-filled
+	empty := &Array[int32]{Data: []int32{}, Shape: []int{0}, DType: Int32}
+	if expected := []int32{0, 0}; !reflect.DeepEqual(empty.Resize(2).Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", empty.Resize(2).Data, expected)
+	}
+}
+
+// TestPad tests padding a 2x2 array with one element on each side
+func TestPad(t *testing.T) {
+	// Create test array
+	arr := &Array[int32]{
+		Data:    []int32{1, 2, 3, 4},
+		Shape:   []int{2, 2},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	padded, err := arr.Pad([]int{1, 1}, []int{1, 1}, 0)
+	if err != nil {
+		t.Fatalf("Failed to pad array: %v", err)
+	}
+
+	// Verify shape
+	if !reflect.DeepEqual(padded.Shape, []int{4, 4}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", padded.Shape, []int{4, 4})
+	}
+
+	// Verify data
+	expected := []int32{
+		0, 0, 0, 0,
+		0, 1, 2, 0,
+		0, 3, 4, 0,
+		0, 0, 0, 0,
+	}
+	if !reflect.DeepEqual(padded.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", padded.Data, expected)
+	}
+
+	// Asymmetric padding of a Fortran-order array
+	fortran := &Array[int32]{Data: []int32{1, 3, 2, 4}, Shape: []int{2, 2}, DType: Int32, Fortran: true}
+	padded, err = fortran.Pad([]int{0, 1}, []int{1, 0}, 9)
+	if err != nil {
+		t.Fatalf("Failed to pad array: %v", err)
+	}
+	expected = []int32{9, 1, 2, 9, 3, 4, 9, 9, 9}
+	if !reflect.DeepEqual(padded.Shape, []int{3, 3}) || !reflect.DeepEqual(padded.Data, expected) {
+		t.Errorf("Fortran pad mismatch. Got %v %v, want %v %v", padded.Shape, padded.Data, []int{3, 3}, expected)
+	}
+
+	// Verify the padding widths must match the rank
+	if _, err := arr.Pad([]int{1}, []int{1, 1}, 0); err == nil {
+		t.Error("Expected error for mismatched padding, got nil")
+	}
+	if _, err := arr.Pad([]int{-1, 0}, []int{0, 0}, 0); err == nil {
+		t.Error("Expected error for negative padding, got nil")
+	}
+}
+
+// TestRoll tests cyclic shifts in both directions and along an axis
+func TestRoll(t *testing.T) {
+	// Create test array
+	arr := &Array[int32]{
+		Data:    []int32{1, 2, 3, 4},
+		Shape:   []int{4},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	rolled, err := arr.Roll(1, 0)
+	if err != nil {
+		t.Fatalf("Failed to roll array: %v", err)
+	}
+	if expected := []int32{4, 1, 2, 3}; !reflect.DeepEqual(rolled.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", rolled.Data, expected)
+	}
+
+	rolled, err = arr.Roll(-1, 0)
+	if err != nil {
+		t.Fatalf("Failed to roll array: %v", err)
+	}
+	if expected := []int32{2, 3, 4, 1}; !reflect.DeepEqual(rolled.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", rolled.Data, expected)
+	}
+
+	// Roll the columns of a 2x3 matrix
+	matrix := &Array[int32]{Data: []int32{1, 2, 3, 4, 5, 6}, Shape: []int{2, 3}, DType: Int32}
+	rolled, err = matrix.Roll(4, -1)
+	if err != nil {
+		t.Fatalf("Failed to roll matrix: %v", err)
+	}
+	if expected := []int32{3, 1, 2, 6, 4, 5}; !reflect.DeepEqual(rolled.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", rolled.Data, expected)
+	}
+
+	// Verify the axis must exist
+	if _, err := arr.Roll(1, 1); err == nil {
+		t.Error("Expected error for invalid axis, got nil")
+	}
+}
+
+// TestFromColumns tests assembling a 2D array from column slices
+func TestFromColumns(t *testing.T) {
+	arr, err := FromColumns(
+		[]int32{1, 2, 3, 4},
+		[]int32{5, 6, 7, 8},
+		[]int32{9, 10, 11, 12},
+	)
+	if err != nil {
+		t.Fatalf("Failed to build array: %v", err)
+	}
+
+	expected := []int32{1, 5, 9, 2, 6, 10, 3, 7, 11, 4, 8, 12}
+	if !reflect.DeepEqual(arr.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", arr.Data, expected)
+	}
+	if !reflect.DeepEqual(arr.Shape, []int{4, 3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", arr.Shape, []int{4, 3})
+	}
+	if arr.DType != Int32 || arr.Fortran {
+		t.Errorf("Metadata mismatch. Got %v/%v, want %v/false", arr.DType, arr.Fortran, Int32)
+	}
+
+	// Ragged and empty inputs are rejected
+	if _, err := FromColumns([]float64{1, 2}, []float64{3}); err == nil {
+		t.Error("Expected error for unequal column lengths, got nil")
+	}
+	if _, err := FromColumns[float64](); err == nil {
+		t.Error("Expected error for no columns, got nil")
+	}
+}
@@ -0,0 +1,58 @@
+package npy
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// customDType describes a dtype registered with RegisterDType
+type customDType struct {
+	itemSize int
+	read     func(io.Reader, int) (interface{}, error)
+	write    func(io.Writer, interface{}) error
+}
+
+var (
+	customDTypesMu sync.RWMutex
+	customDTypes   = make(map[string]customDType)
+)
+
+// RegisterDType adds support for a dtype this package doesn't know, such as
+// bfloat16. code is the descriptor without its byte order prefix, for example
+// "V2", and arrays of the type use DType(code).
+//
+// read must decode n elements from r and return them as a []T, where T is the
+// element type used with Read; write receives an array's Data as a []T and
+// must encode it. Both see the data bytes exactly as stored, so they are
+// responsible for byte order. Registered types are always written with a
+// little-endian ("<") or, for single-byte types, "|" prefix. Readers that
+// choose the element type themselves, such as ReadNPZFile, cannot decode them.
+//
+// Like database/sql.Register, RegisterDType panics if code is empty, already
+// registered or a built-in dtype, or if itemSize or either function is invalid.
+func RegisterDType(code string, itemSize int, read func(io.Reader, int) (interface{}, error), write func(io.Writer, interface{}) error) {
+	if code == "" || itemSize <= 0 || read == nil || write == nil {
+		panic(fmt.Sprintf("npy: invalid registration for dtype %q", code))
+	}
+	// Reject built-in DType names such as "int8" as well as descriptor codes
+	// such as "i1", since arrays of either would be routed to the custom type
+	if DType(code).ItemSize() != 0 {
+		panic(fmt.Sprintf("npy: dtype %q is already supported", code))
+	}
+	if _, err := parseHeader(fmt.Sprintf("{'descr': '<%s', 'fortran_order': False, 'shape': (), }", code)); err == nil {
+		panic(fmt.Sprintf("npy: dtype %q is already supported", code))
+	}
+
+	customDTypesMu.Lock()
+	defer customDTypesMu.Unlock()
+	customDTypes[code] = customDType{itemSize: itemSize, read: read, write: write}
+}
+
+// lookupDType returns the registration for a custom dtype
+func lookupDType(d DType) (customDType, bool) {
+	customDTypesMu.RLock()
+	defer customDTypesMu.RUnlock()
+	custom, ok := customDTypes[string(d)]
+	return custom, ok
+}
@@ -0,0 +1,104 @@
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// brain16 is a stand-in for a 2-byte float type such as bfloat16
+type brain16 uint16
+
+// TestRegisterDType tests round-tripping a registered 2-byte dtype
+func TestRegisterDType(t *testing.T) {
+	RegisterDType("V2", 2,
+		func(r io.Reader, n int) (interface{}, error) {
+			raw := make([]uint16, n)
+			if err := binary.Read(r, binary.LittleEndian, raw); err != nil {
+				return nil, err
+			}
+			data := make([]brain16, n)
+			for i, v := range raw {
+				data[i] = brain16(v)
+			}
+			return data, nil
+		},
+		func(w io.Writer, data interface{}) error {
+			vals, ok := data.([]brain16)
+			if !ok {
+				return fmt.Errorf("unexpected data type %T", data)
+			}
+			raw := make([]uint16, len(vals))
+			for i, v := range vals {
+				raw[i] = uint16(v)
+			}
+			return binary.Write(w, binary.LittleEndian, raw)
+		})
+
+	dtype := DType("V2")
+	if dtype.ItemSize() != 2 {
+		t.Errorf("ItemSize mismatch. Got %d, want 2", dtype.ItemSize())
+	}
+	if desc := dtype.Descriptor(nil); desc != "<V2" {
+		t.Errorf("Descriptor mismatch. Got %q, want %q", desc, "<V2")
+	}
+
+	// Round-trip an array of the registered type
+	arr := &Array[brain16]{Data: []brain16{0x3f80, 0x4000, 0xbf80, 0}, Shape: []int{2, 2}, DType: dtype}
+	var buf bytes.Buffer
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("'descr': '<V2'")) {
+		t.Errorf("Expected <V2 descriptor in header, got %q", buf.Bytes())
+	}
+
+	read, err := Read[brain16](&buf)
+	if err != nil {
+		t.Fatalf("Failed to read array: %v", err)
+	}
+	if read.DType != dtype {
+		t.Errorf("DType mismatch. Got %v, want %v", read.DType, dtype)
+	}
+	if !reflect.DeepEqual(read.Data, arr.Data) || !reflect.DeepEqual(read.Shape, arr.Shape) {
+		t.Errorf("Array mismatch. Got %v %v, want %v %v", read.Data, read.Shape, arr.Data, arr.Shape)
+	}
+
+	// Reading with the wrong Go type fails cleanly
+	buf.Reset()
+	if err := Write(&buf, arr); err != nil {
+		t.Fatalf("Failed to write array: %v", err)
+	}
+	if _, err := Read[uint16](&buf); err == nil {
+		t.Error("Expected error reading with the wrong type, got nil")
+	}
+
+	// Built-in and duplicate registrations panic
+	for _, code := range []string{"f8", "V2", "int8", "float64", "bool"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic registering %q", code)
+				}
+			}()
+			RegisterDType(code, 8, func(io.Reader, int) (interface{}, error) { return nil, nil }, func(io.Writer, interface{}) error { return nil })
+		}()
+	}
+
+	// Built-in arrays still use the built-in codec
+	ints := &Array[int8]{Data: []int8{-1, 2}, Shape: []int{2}, DType: Int8}
+	buf.Reset()
+	if err := Write(&buf, ints); err != nil {
+		t.Fatalf("Failed to write int8 array: %v", err)
+	}
+	readInts, err := Read[int8](&buf)
+	if err != nil {
+		t.Fatalf("Failed to read int8 array: %v", err)
+	}
+	if !reflect.DeepEqual(readInts.Data, ints.Data) {
+		t.Errorf("Data mismatch. Got %v, want %v", readInts.Data, ints.Data)
+	}
+}
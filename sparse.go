@@ -0,0 +1,162 @@
+package npy
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ReadScipySparse reads a sparse matrix saved by scipy.sparse.save_npz and
+// returns it as a dense 2D float64 array. CSR and CSC formats are supported;
+// duplicate entries are summed, as scipy does.
+func ReadScipySparse(path string) (*Array[float64], error) {
+	// The format entry holds a byte string, which the array readers reject,
+	// so each entry is read on its own rather than through ReadNPZ
+	zipReader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open NPZ file: %w", err)
+	}
+	defer zipReader.Close()
+
+	entries := make(map[string]*zip.File)
+	for _, f := range zipReader.File {
+		entries[npzEntryName(f.Name)] = f
+	}
+
+	formatEntry, ok := entries["format"]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a scipy sparse archive: no format entry", path)
+	}
+	format, err := readScipyFormat(formatEntry)
+	if err != nil {
+		return nil, err
+	}
+	if format != "csr" && format != "csc" {
+		return nil, fmt.Errorf("unsupported sparse format: %s", format)
+	}
+
+	// Collect the components as float64
+	parts := make(map[string][]float64)
+	for _, name := range []string{"data", "indices", "indptr", "shape"} {
+		f, ok := entries[name]
+		if !ok {
+			return nil, fmt.Errorf("sparse archive is missing %s", name)
+		}
+		// Read through the NPZ entry path so the size limits apply
+		array, err := readNPZEntry(f, name, nil)
+		if err != nil {
+			return nil, err
+		}
+		converted, ok := toFloat64Array(array)
+		if !ok {
+			return nil, fmt.Errorf("unsupported %s array type %T", name, array)
+		}
+		parts[name] = converted.Data
+	}
+
+	shape := parts["shape"]
+	if len(shape) != 2 {
+		return nil, fmt.Errorf("sparse shape must have 2 dimensions, got %d", len(shape))
+	}
+	for _, dim := range shape {
+		if dim < 0 || dim != math.Trunc(dim) || dim >= math.MaxInt {
+			return nil, fmt.Errorf("invalid sparse shape %v", shape)
+		}
+	}
+	rows, cols := int(shape[0]), int(shape[1])
+
+	// The dense result must respect the same limit as any NPZ entry
+	if cols != 0 && int64(rows) > MaxNPZEntrySize/8/int64(cols) {
+		return nil, fmt.Errorf("dense size of shape %v exceeds limit of %d bytes", shape, MaxNPZEntrySize)
+	}
+
+	// CSR compresses rows and indexes columns; CSC is the transpose
+	major, minor := rows, cols
+	if format == "csc" {
+		major, minor = cols, rows
+	}
+
+	data, indices, indptr := parts["data"], parts["indices"], parts["indptr"]
+	if len(indptr) != major+1 {
+		return nil, fmt.Errorf("indptr has %d entries, want %d", len(indptr), major+1)
+	}
+	if len(indices) != len(data) {
+		return nil, fmt.Errorf("indices has %d entries but data has %d", len(indices), len(data))
+	}
+
+	dense := make([]float64, rows*cols)
+	for i := 0; i < major; i++ {
+		start, end := int(indptr[i]), int(indptr[i+1])
+		if start < 0 || end < start || end > len(data) {
+			return nil, fmt.Errorf("invalid indptr range [%d, %d) at %d", start, end, i)
+		}
+		for k := start; k < end; k++ {
+			j := int(indices[k])
+			if j < 0 || j >= minor {
+				return nil, fmt.Errorf("index %d out of range for dimension of size %d", j, minor)
+			}
+			if format == "csr" {
+				dense[i*cols+j] += data[k]
+			} else {
+				dense[j*cols+i] += data[k]
+			}
+		}
+	}
+
+	return &Array[float64]{
+		Data:  dense,
+		Shape: []int{rows, cols},
+		DType: Float64,
+	}, nil
+}
+
+// readScipyFormat decodes the 0-D byte or unicode string in a scipy format entry
+func readScipyFormat(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s in NPZ: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	_, dict, _, err := readRawHeader(rc)
+	if err != nil {
+		return "", fmt.Errorf("failed to read header from %s: %w", f.Name, err)
+	}
+	descrRe := regexp.MustCompile(`['"]descr['"]:\s*['"]([<>|=]?)([SU])(\d+)['"]`)
+	match := descrRe.FindStringSubmatch(dict)
+	if match == nil {
+		return "", fmt.Errorf("unexpected format entry header: %s", dict)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(rc, 1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+
+	// Byte strings are NUL-padded; unicode strings are NUL-padded UTF-32
+	if match[2] == "S" {
+		return strings.TrimRight(string(raw), "\x00"), nil
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if match[1] == ">" {
+		order = binary.BigEndian
+	}
+	var buf bytes.Buffer
+	for i := 0; i+4 <= len(raw); i += 4 {
+		r := rune(order.Uint32(raw[i:]))
+		if r == 0 {
+			break
+		}
+		if !utf8.ValidRune(r) {
+			return "", fmt.Errorf("invalid character in %s", f.Name)
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,118 @@
+package npy
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeSparseNPZ writes a scipy-style sparse archive with the given format entry
+func writeSparseNPZ(t *testing.T, path, formatDescr string, format []byte, data []float64, indices, indptr []int32, shape []int64) {
+	t.Helper()
+	encode := func(write func(*bytes.Buffer) error) []byte {
+		var buf bytes.Buffer
+		if err := write(&buf); err != nil {
+			t.Fatalf("Failed to encode array: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	writeRawNPZ(t, path, map[string][]byte{
+		"data.npy": encode(func(b *bytes.Buffer) error {
+			return Write(b, &Array[float64]{Data: data, Shape: []int{len(data)}, DType: Float64})
+		}),
+		"indices.npy": encode(func(b *bytes.Buffer) error {
+			return Write(b, &Array[int32]{Data: indices, Shape: []int{len(indices)}, DType: Int32})
+		}),
+		"indptr.npy": encode(func(b *bytes.Buffer) error {
+			return Write(b, &Array[int32]{Data: indptr, Shape: []int{len(indptr)}, DType: Int32})
+		}),
+		"shape.npy": encode(func(b *bytes.Buffer) error {
+			return Write(b, &Array[int64]{Data: shape, Shape: []int{len(shape)}, DType: Int64})
+		}),
+		"format.npy": rawNPY("{'descr': '"+formatDescr+"', 'fortran_order': False, 'shape': (), }", format),
+	})
+}
+
+// TestReadScipySparse tests densifying CSR and CSC archives
+func TestReadScipySparse(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// The matrix [[1, 0, 2], [0, 0, 3]]
+	expected := []float64{1, 0, 2, 0, 0, 3}
+
+	// CSR stores rows
+	csrPath := filepath.Join(tempDir, "csr.npz")
+	writeSparseNPZ(t, csrPath, "|S3", []byte("csr"), []float64{1, 2, 3}, []int32{0, 2, 2}, []int32{0, 2, 3}, []int64{2, 3})
+	dense, err := ReadScipySparse(csrPath)
+	if err != nil {
+		t.Fatalf("Failed to read CSR archive: %v", err)
+	}
+	if !reflect.DeepEqual(dense.Shape, []int{2, 3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", dense.Shape, []int{2, 3})
+	}
+	if !reflect.DeepEqual(dense.Data, expected) {
+		t.Errorf("CSR data mismatch. Got %v, want %v", dense.Data, expected)
+	}
+
+	// CSC stores columns, here with a unicode format string
+	cscPath := filepath.Join(tempDir, "csc.npz")
+	format := []byte{'c', 0, 0, 0, 's', 0, 0, 0, 'c', 0, 0, 0}
+	writeSparseNPZ(t, cscPath, "<U3", format, []float64{1, 2, 3}, []int32{0, 0, 1}, []int32{0, 1, 1, 3}, []int64{2, 3})
+	dense, err = ReadScipySparse(cscPath)
+	if err != nil {
+		t.Fatalf("Failed to read CSC archive: %v", err)
+	}
+	if !reflect.DeepEqual(dense.Data, expected) {
+		t.Errorf("CSC data mismatch. Got %v, want %v", dense.Data, expected)
+	}
+
+	// Other formats are rejected
+	cooPath := filepath.Join(tempDir, "coo.npz")
+	writeSparseNPZ(t, cooPath, "|S3", []byte("coo"), []float64{1}, []int32{0}, []int32{0, 1}, []int64{1, 1})
+	if _, err := ReadScipySparse(cooPath); err == nil {
+		t.Error("Expected error for COO format, got nil")
+	}
+
+	// Negative and oversized shapes are rejected before allocating
+	for i, shape := range [][]int64{{-1, 3}, {2, -3}, {1 << 40, 1 << 20}} {
+		badPath := filepath.Join(tempDir, fmt.Sprintf("bad%d.npz", i))
+		writeSparseNPZ(t, badPath, "|S3", []byte("csr"), []float64{}, []int32{}, []int32{0}, shape)
+		if _, err := ReadScipySparse(badPath); err == nil {
+			t.Errorf("Expected error for shape %v, got nil", shape)
+		}
+	}
+
+	// Components go through the NPZ entry size checks
+	overclaim := filepath.Join(tempDir, "overclaim.npz")
+	writeSparseNPZ(t, overclaim, "|S3", []byte("csr"), []float64{1}, []int32{0}, []int32{0, 1}, []int64{1, 1})
+	entries := map[string][]byte{}
+	zr, err := zip.OpenReader(overclaim)
+	if err != nil {
+		t.Fatalf("Failed to open NPZ file: %v", err)
+	}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Failed to open entry: %v", err)
+		}
+		entries[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	zr.Close()
+	entries["data.npy"] = rawNPY("{'descr': '<f8', 'fortran_order': False, 'shape': (1000000000000,), }", make([]byte, 8))
+	writeRawNPZ(t, overclaim, entries)
+	if _, err := ReadScipySparse(overclaim); err == nil {
+		t.Error("Expected error for overclaiming data entry, got nil")
+	}
+}
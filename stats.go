@@ -0,0 +1,335 @@
+package npy
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// MinMaxNormalize returns a new array with values scaled linearly into [0, 1]
+// over the flat data. If all values are equal the result is all zeros.
+func MinMaxNormalize[T Float](a *Array[T]) *Array[T] {
+	data := make([]T, len(a.Data))
+	if len(a.Data) > 0 {
+		min, max := a.Data[0], a.Data[0]
+		for _, val := range a.Data {
+			if val < min {
+				min = val
+			}
+			if val > max {
+				max = val
+			}
+		}
+
+		// Leave the data as zeros when the range is degenerate
+		if span := max - min; span != 0 {
+			for i, val := range a.Data {
+				data[i] = (val - min) / span
+			}
+		}
+	}
+
+	return &Array[T]{
+		Data:    data,
+		Shape:   append([]int(nil), a.Shape...),
+		DType:   a.DType,
+		Fortran: a.Fortran,
+	}
+}
+
+// ZScoreNormalize returns a new array with values centered to mean 0 and scaled
+// to standard deviation 1 over the flat data. If all values are equal the
+// result is all zeros.
+func ZScoreNormalize[T Float](a *Array[T]) *Array[T] {
+	data := make([]T, len(a.Data))
+	if len(a.Data) > 0 {
+		// Calculate mean and population standard deviation in float64
+		var sum float64
+		for _, val := range a.Data {
+			sum += float64(val)
+		}
+		mean := sum / float64(len(a.Data))
+
+		var sqSum float64
+		for _, val := range a.Data {
+			diff := float64(val) - mean
+			sqSum += diff * diff
+		}
+		std := math.Sqrt(sqSum / float64(len(a.Data)))
+
+		// Leave the data as zeros when there is no spread
+		if std != 0 {
+			for i, val := range a.Data {
+				data[i] = T((float64(val) - mean) / std)
+			}
+		}
+	}
+
+	return &Array[T]{
+		Data:    data,
+		Shape:   append([]int(nil), a.Shape...),
+		DType:   a.DType,
+		Fortran: a.Fortran,
+	}
+}
+
+// Histogram computes an equal-width histogram over the flat data, like
+// np.histogram. The returned edges span the data minimum to maximum with
+// bins+1 entries; every bin is half-open except the last, which includes the
+// maximum. Like np.histogram, it returns an error if the data contains NaN or
+// an infinity, since the range would not be finite.
+func Histogram[T Numeric](a *Array[T], bins int) (counts []int, edges []float64, err error) {
+	if bins < 1 {
+		return nil, nil, fmt.Errorf("number of bins must be positive, got %d", bins)
+	}
+	if len(a.Data) == 0 {
+		return nil, nil, fmt.Errorf("cannot compute histogram of empty array")
+	}
+
+	// Find the data range
+	min, max := float64(a.Data[0]), float64(a.Data[0])
+	for _, val := range a.Data {
+		v := float64(val)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, nil, fmt.Errorf("histogram range is not finite: data contains %v", v)
+		}
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	if math.IsInf(max-min, 0) {
+		return nil, nil, fmt.Errorf("histogram range [%v, %v] is too wide", min, max)
+	}
+
+	// Widen a degenerate range the same way NumPy does
+	if min == max {
+		min -= 0.5
+		max += 0.5
+	}
+
+	// Calculate bin edges
+	width := (max - min) / float64(bins)
+	edges = make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = min + float64(i)*width
+	}
+	edges[bins] = max
+
+	// Count values into bins
+	counts = make([]int, bins)
+	for _, val := range a.Data {
+		idx := int((float64(val) - min) / width)
+		if idx >= bins {
+			idx = bins - 1
+		}
+		counts[idx]++
+	}
+
+	return counts, edges, nil
+}
+
+// ArgMax returns the flat index of the first maximum value and its N-D
+// coordinate computed from the array's shape and order. For an empty array
+// it returns -1 and a nil coordinate.
+func ArgMax[T Numeric](a *Array[T]) (flat int, coord []int) {
+	return argExtreme(a, func(v, best T) bool { return v > best })
+}
+
+// ArgMin returns the flat index of the first minimum value and its N-D
+// coordinate computed from the array's shape and order. For an empty array
+// it returns -1 and a nil coordinate.
+func ArgMin[T Numeric](a *Array[T]) (flat int, coord []int) {
+	return argExtreme(a, func(v, best T) bool { return v < best })
+}
+
+// argExtreme finds the first element preferred over all others by better
+func argExtreme[T Numeric](a *Array[T], better func(v, best T) bool) (int, []int) {
+	if len(a.Data) == 0 {
+		return -1, nil
+	}
+
+	flat := 0
+	for i, val := range a.Data {
+		if better(val, a.Data[flat]) {
+			flat = i
+		}
+	}
+
+	return flat, unravelIndex(flat, a.Shape, a.Fortran)
+}
+
+// Sorted returns a 1D copy of the flat data sorted in ascending order
+func Sorted[T Numeric](a *Array[T]) *Array[T] {
+	data := append([]T(nil), a.Data...)
+	slices.Sort(data)
+
+	return &Array[T]{
+		Data:  data,
+		Shape: []int{len(data)},
+		DType: a.DType,
+	}
+}
+
+// Unique returns the sorted distinct values of the flat data and the number
+// of times each occurs, like np.unique with return_counts. NaN values are
+// counted together.
+func Unique[T Numeric](a *Array[T]) (values []T, counts []int) {
+	sorted := append([]T(nil), a.Data...)
+	slices.Sort(sorted)
+
+	for i, val := range sorted {
+		if i > 0 && cmp.Compare(val, sorted[i-1]) == 0 {
+			counts[len(counts)-1]++
+			continue
+		}
+		values = append(values, val)
+		counts = append(counts, 1)
+	}
+	return values, counts
+}
+
+// Mode returns the most frequent value in the flat data and its count. Ties
+// are broken in favor of the smallest value. For an empty array it returns
+// the zero value and a count of 0.
+func Mode[T Numeric](a *Array[T]) (value T, count int) {
+	values, counts := Unique(a)
+	for i, c := range counts {
+		if c > count {
+			value, count = values[i], c
+		}
+	}
+	return value, count
+}
+
+// Argsort returns the flat indices that would sort the data in ascending
+// order. Equal elements keep their original relative order.
+func Argsort[T Numeric](a *Array[T]) []int {
+	indices := make([]int, len(a.Data))
+	for i := range indices {
+		indices[i] = i
+	}
+	slices.SortStableFunc(indices, func(i, j int) int {
+		return cmp.Compare(a.Data[i], a.Data[j])
+	})
+	return indices
+}
+
+// Percentile computes the p-th percentile of the flat data using linear
+// interpolation between the closest ranks, matching NumPy's default method.
+// Like np.percentile, it returns NaN if the data contains NaN.
+func Percentile[T Numeric](a *Array[T], p float64) (float64, error) {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, fmt.Errorf("percentile must be between 0 and 100, got %v", p)
+	}
+	if len(a.Data) == 0 {
+		return 0, fmt.Errorf("cannot compute percentile of empty array")
+	}
+	for _, val := range a.Data {
+		if math.IsNaN(float64(val)) {
+			return math.NaN(), nil
+		}
+	}
+
+	sorted := Sorted(a).Data
+
+	// Interpolate between the neighbouring ranks
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	loVal, hiVal := float64(sorted[lo]), float64(sorted[hi])
+
+	return loVal + (hiVal-loVal)*(rank-float64(lo)), nil
+}
+
+// HasNaN reports whether any element of the array is NaN
+func HasNaN[T Float](a *Array[T]) bool {
+	for _, val := range a.Data {
+		if math.IsNaN(float64(val)) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasInf reports whether any element of the array is positive or negative infinity
+func HasInf[T Float](a *Array[T]) bool {
+	for _, val := range a.Data {
+		if math.IsInf(float64(val), 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// CountNaN returns the number of NaN elements in the array
+func CountNaN[T Float](a *Array[T]) int {
+	count := 0
+	for _, val := range a.Data {
+		if math.IsNaN(float64(val)) {
+			count++
+		}
+	}
+	return count
+}
+
+// NanToNum returns a new array with NaN, positive infinity and negative
+// infinity replaced by the given values
+func NanToNum[T Float](a *Array[T], nan, posInf, negInf T) *Array[T] {
+	data := make([]T, len(a.Data))
+	for i, val := range a.Data {
+		v := float64(val)
+		switch {
+		case math.IsNaN(v):
+			val = nan
+		case math.IsInf(v, 1):
+			val = posInf
+		case math.IsInf(v, -1):
+			val = negInf
+		}
+		data[i] = val
+	}
+
+	return &Array[T]{
+		Data:    data,
+		Shape:   append([]int(nil), a.Shape...),
+		DType:   a.DType,
+		Fortran: a.Fortran,
+	}
+}
+
+// NanToNumDefault returns a new array with NaN replaced by zero and infinities
+// replaced by the largest finite values of T, matching np.nan_to_num
+func NanToNumDefault[T Float](a *Array[T]) *Array[T] {
+	max := math.MaxFloat64
+	if dtypeOf[T]() == Float32 {
+		max = math.MaxFloat32
+	}
+	return NanToNum(a, 0, T(max), T(-max))
+}
+
+// CumSum returns the running sum of the data flattened in C order, like
+// np.cumsum without an axis, as a 1D float64 array of the same length
+func CumSum[T Numeric](a *Array[T]) *Array[float64] {
+	src := a.Data
+	if a.Fortran {
+		src = toCOrder(a.Data, a.Shape)
+	}
+
+	data := make([]float64, len(src))
+	sum := 0.0
+	for i, val := range src {
+		sum += float64(val)
+		data[i] = sum
+	}
+
+	return &Array[float64]{
+		Data:  data,
+		Shape: []int{len(data)},
+		DType: Float64,
+	}
+}
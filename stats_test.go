@@ -0,0 +1,404 @@
+package npy
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestMinMaxNormalize tests scaling an array into [0, 1]
+func TestMinMaxNormalize(t *testing.T) {
+	// Create test array
+	arr := &Array[float64]{
+		Data:    []float64{2.0, 4.0, 6.0, 10.0},
+		Shape:   []int{2, 2},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	norm := MinMaxNormalize(arr)
+
+	// Verify data
+	expected := []float64{0.0, 0.25, 0.5, 1.0}
+	for i := range expected {
+		if math.Abs(norm.Data[i]-expected[i]) > 1e-12 {
+			t.Errorf("Data mismatch at %d. Got %v, want %v", i, norm.Data[i], expected[i])
+		}
+	}
+
+	// Verify the degenerate case produces zeros
+	flat := &Array[float32]{
+		Data:  []float32{3, 3, 3},
+		Shape: []int{3},
+		DType: Float32,
+	}
+	for i, val := range MinMaxNormalize(flat).Data {
+		if val != 0 {
+			t.Errorf("Expected zero at %d for constant array, got %v", i, val)
+		}
+	}
+}
+
+// TestZScoreNormalize tests centering an array to mean 0 and std 1
+func TestZScoreNormalize(t *testing.T) {
+	// Create test array
+	arr := &Array[float64]{
+		Data:    []float64{1.0, 2.0, 3.0, 4.0, 5.0},
+		Shape:   []int{5},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	norm := ZScoreNormalize(arr)
+
+	// Verify mean and standard deviation of the result
+	var sum float64
+	for _, val := range norm.Data {
+		sum += val
+	}
+	mean := sum / float64(len(norm.Data))
+
+	var sqSum float64
+	for _, val := range norm.Data {
+		sqSum += (val - mean) * (val - mean)
+	}
+	std := math.Sqrt(sqSum / float64(len(norm.Data)))
+
+	if math.Abs(mean) > 1e-12 {
+		t.Errorf("Expected mean 0, got %v", mean)
+	}
+	if math.Abs(std-1) > 1e-12 {
+		t.Errorf("Expected std 1, got %v", std)
+	}
+
+	// Verify the degenerate case produces zeros
+	flat := &Array[float64]{
+		Data:  []float64{7, 7},
+		Shape: []int{2},
+		DType: Float64,
+	}
+	for i, val := range ZScoreNormalize(flat).Data {
+		if val != 0 {
+			t.Errorf("Expected zero at %d for constant array, got %v", i, val)
+		}
+	}
+}
+
+// TestHistogram tests computing bucket counts over a known dataset
+func TestHistogram(t *testing.T) {
+	// Create test array with values spanning [0, 10]
+	arr := &Array[int32]{
+		Data:    []int32{0, 1, 2, 2, 5, 7, 9, 10},
+		Shape:   []int{8},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	counts, edges, err := Histogram(arr, 5)
+	if err != nil {
+		t.Fatalf("Failed to compute histogram: %v", err)
+	}
+
+	// Verify counts - the last bin includes the maximum
+	expectedCounts := []int{2, 2, 1, 1, 2}
+	if !reflect.DeepEqual(counts, expectedCounts) {
+		t.Errorf("Counts mismatch. Got %v, want %v", counts, expectedCounts)
+	}
+
+	// Verify edges
+	expectedEdges := []float64{0, 2, 4, 6, 8, 10}
+	if !reflect.DeepEqual(edges, expectedEdges) {
+		t.Errorf("Edges mismatch. Got %v, want %v", edges, expectedEdges)
+	}
+
+	// Verify invalid inputs are rejected
+	if _, _, err := Histogram(arr, 0); err == nil {
+		t.Error("Expected error for zero bins, got nil")
+	}
+	empty := &Array[int32]{Data: []int32{}, Shape: []int{0}, DType: Int32}
+	if _, _, err := Histogram(empty, 3); err == nil {
+		t.Error("Expected error for empty array, got nil")
+	}
+
+	// Non-finite data has no finite range
+	for _, data := range [][]float64{
+		{math.NaN(), 1, 2},
+		{1, 2, math.NaN()},
+		{1, math.Inf(1)},
+		{math.Inf(-1), 0},
+		{-math.MaxFloat64, math.MaxFloat64},
+	} {
+		nonFinite := &Array[float64]{Data: data, Shape: []int{len(data)}, DType: Float64}
+		if _, _, err := Histogram(nonFinite, 2); err == nil {
+			t.Errorf("Expected error for %v, got nil", data)
+		}
+	}
+}
+
+// TestArgMaxArgMin tests locating extreme values in a 3x3 array
+func TestArgMaxArgMin(t *testing.T) {
+	// Create test array - 3x3 matrix with maximum at (2,1) and minimum at (0,2)
+	arr := &Array[float64]{
+		Data:    []float64{4, 5, -3, 1, 0, 2, 6, 9, 8},
+		Shape:   []int{3, 3},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	// Verify maximum location
+	flat, coord := ArgMax(arr)
+	if flat != 7 {
+		t.Errorf("ArgMax flat index mismatch. Got %d, want %d", flat, 7)
+	}
+	if !reflect.DeepEqual(coord, []int{2, 1}) {
+		t.Errorf("ArgMax coordinate mismatch. Got %v, want %v", coord, []int{2, 1})
+	}
+
+	// Verify minimum location
+	flat, coord = ArgMin(arr)
+	if flat != 2 {
+		t.Errorf("ArgMin flat index mismatch. Got %d, want %d", flat, 2)
+	}
+	if !reflect.DeepEqual(coord, []int{0, 2}) {
+		t.Errorf("ArgMin coordinate mismatch. Got %v, want %v", coord, []int{0, 2})
+	}
+
+	// Verify Fortran order is honored - flat index 7 is (1,2) in column-major
+	arr.Fortran = true
+	_, coord = ArgMax(arr)
+	if !reflect.DeepEqual(coord, []int{1, 2}) {
+		t.Errorf("Fortran ArgMax coordinate mismatch. Got %v, want %v", coord, []int{1, 2})
+	}
+}
+
+// TestSortedArgsort tests sorting an unsorted int array
+func TestSortedArgsort(t *testing.T) {
+	// Create test array
+	arr := &Array[int64]{
+		Data:    []int64{5, -2, 9, 0, 3},
+		Shape:   []int{5},
+		DType:   Int64,
+		Fortran: false,
+	}
+
+	// Verify sorted copy
+	sorted := Sorted(arr)
+	expected := []int64{-2, 0, 3, 5, 9}
+	if !reflect.DeepEqual(sorted.Data, expected) {
+		t.Errorf("Sorted data mismatch. Got %v, want %v", sorted.Data, expected)
+	}
+	if sorted.DType != Int64 {
+		t.Errorf("DType mismatch. Got %v, want %v", sorted.DType, Int64)
+	}
+
+	// Verify the original array is unchanged
+	if !reflect.DeepEqual(arr.Data, []int64{5, -2, 9, 0, 3}) {
+		t.Errorf("Original array was modified: %v", arr.Data)
+	}
+
+	// Verify permutation
+	indices := Argsort(arr)
+	expectedIndices := []int{1, 3, 4, 0, 2}
+	if !reflect.DeepEqual(indices, expectedIndices) {
+		t.Errorf("Argsort mismatch. Got %v, want %v", indices, expectedIndices)
+	}
+}
+
+// TestPercentile tests computing percentiles against hand-computed values
+func TestPercentile(t *testing.T) {
+	// Create test array - sorted this is [1, 2, 4, 7]
+	arr := &Array[int32]{
+		Data:    []int32{7, 1, 4, 2},
+		Shape:   []int{4},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 1},
+		{50, 3}, // rank 1.5 interpolates between 2 and 4
+		{100, 7},
+	}
+
+	for _, tt := range tests {
+		got, err := Percentile(arr, tt.p)
+		if err != nil {
+			t.Fatalf("Failed to compute percentile %v: %v", tt.p, err)
+		}
+		if got != tt.want {
+			t.Errorf("Percentile(%v) mismatch. Got %v, want %v", tt.p, got, tt.want)
+		}
+	}
+
+	// NaN anywhere in the data makes every percentile NaN
+	withNaN := &Array[float64]{Data: []float64{3, math.NaN(), 1}, Shape: []int{3}, DType: Float64}
+	for _, p := range []float64{0, 50, 100} {
+		got, err := Percentile(withNaN, p)
+		if err != nil {
+			t.Fatalf("Failed to compute percentile %v: %v", p, err)
+		}
+		if !math.IsNaN(got) {
+			t.Errorf("Percentile(%v) of data with NaN mismatch. Got %v, want NaN", p, got)
+		}
+	}
+
+	// Verify out-of-range percentiles are rejected
+	if _, err := Percentile(arr, -1); err == nil {
+		t.Error("Expected error for negative percentile, got nil")
+	}
+	if _, err := Percentile(arr, 101); err == nil {
+		t.Error("Expected error for percentile above 100, got nil")
+	}
+}
+
+// TestNaNInfDetection tests detecting NaN and infinite values
+func TestNaNInfDetection(t *testing.T) {
+	// Create test arrays
+	clean := &Array[float64]{
+		Data:  []float64{1.0, 2.0, 3.0},
+		Shape: []int{3},
+		DType: Float64,
+	}
+	withNaN := &Array[float32]{
+		Data:  []float32{1.0, float32(math.NaN()), 3.0, float32(math.NaN())},
+		Shape: []int{4},
+		DType: Float32,
+	}
+	withInf := &Array[float64]{
+		Data:  []float64{math.Inf(1), 2.0},
+		Shape: []int{2},
+		DType: Float64,
+	}
+
+	// Verify the clean array
+	if HasNaN(clean) || HasInf(clean) || CountNaN(clean) != 0 {
+		t.Errorf("Expected no NaN or Inf in clean array")
+	}
+
+	// Verify NaN detection
+	if !HasNaN(withNaN) {
+		t.Errorf("Expected NaN to be detected")
+	}
+	if HasInf(withNaN) {
+		t.Errorf("Expected no Inf in NaN array")
+	}
+	if count := CountNaN(withNaN); count != 2 {
+		t.Errorf("NaN count mismatch. Got %d, want %d", count, 2)
+	}
+
+	// Verify Inf detection
+	if !HasInf(withInf) {
+		t.Errorf("Expected +Inf to be detected")
+	}
+	if HasNaN(withInf) {
+		t.Errorf("Expected no NaN in Inf array")
+	}
+}
+
+// TestNanToNum tests replacing NaN and infinite values
+func TestNanToNum(t *testing.T) {
+	// Create test array
+	arr := &Array[float64]{
+		Data:    []float64{math.NaN(), 1.5, math.Inf(1), math.Inf(-1)},
+		Shape:   []int{2, 2},
+		DType:   Float64,
+		Fortran: false,
+	}
+
+	// Replace with explicit values
+	replaced := NanToNum(arr, 0, 1e6, -1e6)
+	expected := []float64{0, 1.5, 1e6, -1e6}
+	if !reflect.DeepEqual(replaced.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", replaced.Data, expected)
+	}
+	if !reflect.DeepEqual(replaced.Shape, arr.Shape) {
+		t.Errorf("Shape mismatch. Got %v, want %v", replaced.Shape, arr.Shape)
+	}
+
+	// Verify the original array is unchanged
+	if !math.IsNaN(arr.Data[0]) {
+		t.Errorf("Original array was modified: %v", arr.Data)
+	}
+
+	// Replace with defaults
+	defaults := NanToNumDefault(arr)
+	expected = []float64{0, 1.5, math.MaxFloat64, -math.MaxFloat64}
+	if !reflect.DeepEqual(defaults.Data, expected) {
+		t.Errorf("Default data mismatch. Got %v, want %v", defaults.Data, expected)
+	}
+
+	// Verify float32 defaults use the float32 range
+	arr32 := &Array[float32]{
+		Data:  []float32{float32(math.Inf(1))},
+		Shape: []int{1},
+		DType: Float32,
+	}
+	if got := NanToNumDefault(arr32).Data[0]; got != math.MaxFloat32 {
+		t.Errorf("Float32 default mismatch. Got %v, want %v", got, float32(math.MaxFloat32))
+	}
+}
+
+// TestCumSum tests running sums over 1D and Fortran-order 2D arrays
+func TestCumSum(t *testing.T) {
+	// Create test array
+	arr := &Array[int32]{
+		Data:    []int32{1, 2, 3},
+		Shape:   []int{3},
+		DType:   Int32,
+		Fortran: false,
+	}
+
+	sums := CumSum(arr)
+	if !reflect.DeepEqual(sums.Shape, []int{3}) {
+		t.Errorf("Shape mismatch. Got %v, want %v", sums.Shape, []int{3})
+	}
+	if expected := []float64{1, 3, 6}; !reflect.DeepEqual(sums.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", sums.Data, expected)
+	}
+
+	// Fortran-order data is summed in C order: [[1, 2], [3, 4]]
+	fortran := &Array[float32]{Data: []float32{1, 3, 2, 4}, Shape: []int{2, 2}, DType: Float32, Fortran: true}
+	sums = CumSum(fortran)
+	if expected := []float64{1, 3, 6, 10}; !reflect.DeepEqual(sums.Data, expected) {
+		t.Errorf("Data mismatch. Got %v, want %v", sums.Data, expected)
+	}
+}
+
+// TestUniqueMode tests distinct values, counts and the most frequent value
+func TestUniqueMode(t *testing.T) {
+	// Create test array
+	arr := &Array[int64]{
+		Data:    []int64{3, 1, 3, 2, 1, 3},
+		Shape:   []int{2, 3},
+		DType:   Int64,
+		Fortran: false,
+	}
+
+	values, counts := Unique(arr)
+	if expected := []int64{1, 2, 3}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("Values mismatch. Got %v, want %v", values, expected)
+	}
+	if expected := []int{2, 1, 3}; !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Counts mismatch. Got %v, want %v", counts, expected)
+	}
+
+	mode, count := Mode(arr)
+	if mode != 3 || count != 3 {
+		t.Errorf("Mode mismatch. Got %d (%d times), want 3 (3 times)", mode, count)
+	}
+
+	// Ties go to the smallest value
+	tied := &Array[int64]{Data: []int64{5, 4, 5, 4}, Shape: []int{4}, DType: Int64}
+	if mode, _ := Mode(tied); mode != 4 {
+		t.Errorf("Tied mode mismatch. Got %d, want 4", mode)
+	}
+
+	// Empty arrays have no mode
+	empty := &Array[int64]{Data: []int64{}, Shape: []int{0}, DType: Int64}
+	if mode, count := Mode(empty); mode != 0 || count != 0 {
+		t.Errorf("Expected zero mode for empty array, got %d (%d times)", mode, count)
+	}
+}
@@ -0,0 +1,67 @@
+package npy
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadTarNPY reads every .npy entry in a tar archive into an NPZ file, keyed
+// by entry name without the extension. Other entries are ignored.
+func ReadTarNPY(path string) (*NPZFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tar file: %w", err)
+	}
+	defer f.Close()
+
+	return readTarNPY(f)
+}
+
+// readTarNPY decodes every .npy entry in a tar stream
+func readTarNPY(r io.Reader) (*NPZFile, error) {
+	npz := NewNPZFile()
+	tr := tar.NewReader(r)
+	for {
+		th, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar entry: %w", err)
+		}
+		if th.Typeflag != tar.TypeReg || !strings.HasSuffix(th.Name, ".npy") {
+			continue
+		}
+
+		hdr, preambleLen, err := readHeader(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read header from %s: %w", th.Name, err)
+		}
+
+		// Make sure the declared data fits in the entry before allocating for it
+		size, err := hdr.dataSize()
+		if err == nil {
+			size += int64(preambleLen)
+			if size > MaxNPZEntrySize {
+				err = fmt.Errorf("entry size %d exceeds limit of %d bytes", size, MaxNPZEntrySize)
+			} else if size > th.Size {
+				err = fmt.Errorf("header declares %d bytes but entry holds %d", size, th.Size)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry %s: %w", th.Name, err)
+		}
+
+		array, err := readBodyAny(tr, hdr, ReadOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s array from %s: %w", hdr.DType, th.Name, err)
+		}
+		npz.arrays[npzEntryName(th.Name)] = array
+	}
+
+	return npz, nil
+}
@@ -0,0 +1,116 @@
+package npy
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// TestReadTarNPY tests reading .npy entries from a tar archive
+func TestReadTarNPY(t *testing.T) {
+	// Create temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "npy-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Encode two arrays
+	images := &Array[uint8]{Data: []uint8{0, 128, 255, 64}, Shape: []int{2, 2}, DType: Uint8}
+	labels := &Array[int64]{Data: []int64{3, 7}, Shape: []int{2}, DType: Int64}
+	var imagesBuf, labelsBuf bytes.Buffer
+	if err := Write(&imagesBuf, images); err != nil {
+		t.Fatalf("Failed to write images: %v", err)
+	}
+	if err := Write(&labelsBuf, labels); err != nil {
+		t.Fatalf("Failed to write labels: %v", err)
+	}
+
+	// Build the tar in memory, including an entry that isn't an array
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	entries := []struct {
+		name string
+		data []byte
+	}{
+		{"images.npy", imagesBuf.Bytes()},
+		{"README.txt", []byte("not an array")},
+		{"labels.npy", labelsBuf.Bytes()},
+	}
+	for _, e := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.data))}); err != nil {
+			t.Fatalf("Failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write(e.data); err != nil {
+			t.Fatalf("Failed to write tar entry: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to close tar writer: %v", err)
+	}
+
+	tarPath := filepath.Join(tempDir, "data.tar")
+	if err := os.WriteFile(tarPath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write tar file: %v", err)
+	}
+
+	// Read the archive
+	npz, err := ReadTarNPY(tarPath)
+	if err != nil {
+		t.Fatalf("Failed to read tar file: %v", err)
+	}
+	if keys := Keys(npz); !reflect.DeepEqual(keys, []string{"images", "labels"}) {
+		t.Errorf("Keys mismatch. Got %v, want %v", keys, []string{"images", "labels"})
+	}
+
+	readImages, ok := Get[uint8](npz, "images")
+	if !ok || !reflect.DeepEqual(readImages.Data, images.Data) || !reflect.DeepEqual(readImages.Shape, images.Shape) {
+		t.Errorf("Images mismatch. Got %v", readImages)
+	}
+	readLabels, ok := Get[int64](npz, "labels")
+	if !ok || !reflect.DeepEqual(readLabels.Data, labels.Data) {
+		t.Errorf("Labels mismatch. Got %v", readLabels)
+	}
+}
+
+// TestReadTarNPYTruncated tests that tar entries whose headers overstate their
+// size are rejected without allocating the claimed size
+func TestReadTarNPYTruncated(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape string
+		size  int64
+	}{
+		{"truncated", "(1000000000,)", 8 << 30},
+		{"over limit", "(2000000000,)", 16 << 30},
+	}
+
+	for _, tt := range tests {
+		// The tar header and the array header both claim far more data than follows
+		entry := rawNPY("{'descr': '<f8', 'fortran_order': False, 'shape': "+tt.shape+", }", make([]byte, 8))
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		if err := tw.WriteHeader(&tar.Header{Name: "data.npy", Mode: 0644, Size: tt.size}); err != nil {
+			t.Fatalf("Failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write(entry); err != nil {
+			t.Fatalf("Failed to write tar entry: %v", err)
+		}
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err := readTarNPY(bytes.NewReader(buf.Bytes()))
+		runtime.ReadMemStats(&after)
+
+		if err == nil {
+			t.Errorf("Expected error for %s entry, got nil", tt.name)
+		}
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 64<<20 {
+			t.Errorf("Reading %s entry allocated %d bytes, want at most %d", tt.name, alloc, 64<<20)
+		}
+	}
+}